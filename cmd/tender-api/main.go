@@ -3,10 +3,15 @@ package main
 import (
 	"Backend-trainee-assignment-autumn-2024/internal/config"
 	"Backend-trainee-assignment-autumn-2024/internal/delivery/handler"
-	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils/middleware"
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/notifier"
+	filenotifier "Backend-trainee-assignment-autumn-2024/internal/notifier/file"
+	smtpnotifier "Backend-trainee-assignment-autumn-2024/internal/notifier/smtp"
+	"Backend-trainee-assignment-autumn-2024/internal/publisher"
 	"Backend-trainee-assignment-autumn-2024/internal/repository/postgres"
 	"Backend-trainee-assignment-autumn-2024/internal/router"
 	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"Backend-trainee-assignment-autumn-2024/internal/storage/local"
 	"context"
 	"fmt"
 	"log"
@@ -14,6 +19,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -38,17 +44,70 @@ func main() {
 	organizationRepository := postgres.NewOrganizationRepository(db, logger)
 	bidRepository := postgres.NewBidRepository(db, logger)
 	tenderRepository := postgres.NewTenderRepository(db, logger)
+	attachmentRepository := postgres.NewAttachmentRepository(db, logger)
+	outboxRepository := postgres.NewOutboxRepository(db, logger)
+	webhookRepository := postgres.NewWebhookRepository(db, logger)
+	notificationRepository := postgres.NewNotificationRepository(db, logger)
 
-	tenderService := service.NewTenderService(tenderRepository, organizationRepository, logger)
-	bidService := service.NewBidService(bidRepository, tenderRepository, organizationRepository, userRepository, logger)
+	attachmentStorage, err := local.NewLocalStorage(cfg.AttachmentsDir)
+	if err != nil {
+		slog.Error("failed to init attachment storage", "error", err)
+		os.Exit(1)
+	}
+
+	emailNotifier := notifier.NewNopNotifier()
+	switch cfg.EmailSink {
+	case "smtp":
+		emailNotifier = smtpnotifier.NewSMTPNotifier(smtpnotifier.Config{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+			Timeout:  cfg.EmailTimeout,
+		})
+	case "file":
+		emailNotifier, err = filenotifier.NewFileNotifier(cfg.EmailDir, cfg.EmailFrom)
+		if err != nil {
+			slog.Error("failed to init email notifier", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	tenderTransitions := model.NewTenderStatusTransitions(cfg.TenderReopenStatuses...)
+
+	notificationService := service.NewNotificationService(notificationRepository, userRepository, logger)
+	tenderService := service.NewTenderService(tenderRepository, organizationRepository, tenderTransitions, notificationService, logger)
+	bidService := service.NewBidService(bidRepository, tenderRepository, organizationRepository, userRepository, tenderTransitions, cfg.AuctionExtension, notificationService, logger)
+	organizationService := service.NewOrganizationService(organizationRepository, userRepository, logger)
+	userService := service.NewUserService(userRepository, logger)
+	authService := service.NewAuthService(userRepository, []byte(cfg.JWTSecret), cfg.JWTTTL, logger)
+	attachmentService := service.NewAttachmentService(attachmentRepository, tenderRepository, bidRepository, userRepository, attachmentStorage, cfg.AttachmentMaxSize, cfg.AttachmentTypes, logger)
+	tenderScheduler := service.NewTenderScheduler(tenderRepository, notificationService, cfg.TenderCloseInterval, logger)
+	webhookService := service.NewWebhookService(webhookRepository, organizationRepository, userRepository, logger)
+	webhookDispatcher := service.NewWebhookDispatcher(webhookRepository, service.NewWebhookClient(cfg.WebhookTimeout), cfg.WebhookDispatchInterval, cfg.WebhookMaxAttempts, cfg.WebhookRetryBase, logger)
+	emailDispatcher := service.NewEmailDispatcher(notificationRepository, userRepository, emailNotifier, cfg.EmailDispatchInterval, cfg.EmailTimeout, cfg.EmailMaxAttempts, cfg.EmailRetryBase, logger)
+	broadcaster := publisher.NewBroadcaster()
+	eventService := service.NewEventService(outboxRepository, tenderRepository, userRepository, broadcaster, logger)
+	// Повтор события проходит всех publisher заново: webhook-доставки не дублируются благодаря
+	// уникальности по событию, лог пишется после успеха. Подписчиков потока relay будит после фиксации
+	outboxRelay := service.NewOutboxRelay(outboxRepository, publisher.NewMultiPublisher(webhookDispatcher, publisher.NewLogPublisher(logger)), broadcaster, cfg.OutboxRelayInterval, logger)
 
 	tenderHandler := handler.NewTenderHandler(tenderService, logger)
 	bidHandler := handler.NewBidHandler(bidService, logger)
+	authHandler := handler.NewAuthHandler(authService, logger)
+	organizationHandler := handler.NewOrganizationHandler(organizationService, logger)
+	userHandler := handler.NewUserHandler(userService, logger)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, logger)
+	webhookHandler := handler.NewWebhookHandler(webhookService, logger)
+	eventHandler := handler.NewEventHandler(eventService, cfg.EventsPollInterval, logger)
+	notificationHandler := handler.NewNotificationHandler(notificationService, logger)
 
 	pingHandler := handler.NewPingHandler(logger)
 
-	app := router.SetupRouter(tenderHandler, pingHandler, bidHandler)
-	app.Use(middleware.AuthMiddleware)
+	// Запас сверх размера вложения на заголовки multipart
+	uploadLimit := int(cfg.AttachmentMaxSize) + 1<<20
+	app := router.SetupRouter(tenderHandler, pingHandler, bidHandler, authHandler, organizationHandler, userHandler, attachmentHandler, webhookHandler, eventHandler, notificationHandler, []byte(cfg.JWTSecret), uploadLimit)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -60,6 +119,26 @@ func main() {
 		}
 	}()
 
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	var schedulerWG sync.WaitGroup
+	schedulerWG.Add(4)
+	go func() {
+		defer schedulerWG.Done()
+		tenderScheduler.Run(schedulerCtx)
+	}()
+	go func() {
+		defer schedulerWG.Done()
+		outboxRelay.Run(schedulerCtx)
+	}()
+	go func() {
+		defer schedulerWG.Done()
+		webhookDispatcher.Run(schedulerCtx)
+	}()
+	go func() {
+		defer schedulerWG.Done()
+		emailDispatcher.Run(schedulerCtx)
+	}()
+
 	fmt.Println("Server is running on port", cfg.Port)
 	<-quit
 	log.Println("Shutting down server...")
@@ -67,11 +146,17 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Открытые потоки событий завершаются, иначе сервер ждал бы их до таймаута
+	broadcaster.Close()
 	if err := app.ShutdownWithContext(ctx); err != nil {
 		slog.Error("Server forced to shutdown: ", "error", err)
 		os.Exit(1)
 	}
 
+	// Фоновые задачи останавливаются после сервера, прерванная работа откатывается транзакцией
+	stopScheduler()
+	schedulerWG.Wait()
+
 	log.Println("Server exiting")
 
 }
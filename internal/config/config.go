@@ -1,9 +1,13 @@
 package config
 
 import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +15,42 @@ import (
 type Config struct {
 	DBConnStr string
 	Port      string
+	JWTSecret string
+	JWTTTL    time.Duration
+	// Статусы, в которые разрешено вернуть закрытый тендер
+	TenderReopenStatuses []model.TenderStatus
+	// Как часто проверять тендеры с истёкшим дедлайном
+	TenderCloseInterval time.Duration
+	// Продление редукциона при ставке в последние минуты торгов
+	AuctionExtension time.Duration
+	// Каталог локального хранилища вложений
+	AttachmentsDir string
+	// Максимальный размер вложения в байтах
+	AttachmentMaxSize int64
+	// Разрешённые MIME-типы вложений, определяются по содержимому файла
+	AttachmentTypes []string
+	// Как часто переносить события из outbox в publisher
+	OutboxRelayInterval time.Duration
+	// Как часто отправлять webhook-доставки, время ответа получателя и политика повторов
+	WebhookDispatchInterval time.Duration
+	WebhookTimeout          time.Duration
+	WebhookMaxAttempts      int
+	WebhookRetryBase        time.Duration
+	// Как часто поток событий тендера перечитывает outbox без сигнала от relay
+	EventsPollInterval time.Duration
+	// Куда отправлять письма: smtp, file (каталог EmailDir) или пусто - не отправлять
+	EmailSink    string
+	EmailFrom    string
+	EmailDir     string
+	EmailTimeout time.Duration
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// Как часто отправлять письма из очереди и политика повторов
+	EmailDispatchInterval time.Duration
+	EmailMaxAttempts      int
+	EmailRetryBase        time.Duration
 }
 
 func NewConfig() (*Config, error) {
@@ -38,8 +78,223 @@ func NewConfig() (*Config, error) {
 		port = "8080"
 	}
 
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		slog.Error("JWT_SECRET must be set")
+		return nil, fmt.Errorf("missing required environment variables")
+	}
+
+	jwtTTL := 24 * time.Hour
+	if ttl := os.Getenv("JWT_TTL"); ttl != "" {
+		jwtTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			slog.Error("JWT_TTL must be a valid duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid JWT_TTL: %w", err)
+		}
+	}
+
+	var reopenStatuses []model.TenderStatus
+	if statuses := os.Getenv("TENDER_REOPEN_STATUSES"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.TrimSpace(status)
+			if status != string(model.TenderStatusCreated) && status != string(model.TenderStatusPublished) {
+				slog.Error("TENDER_REOPEN_STATUSES must contain only Created or Published", slog.String("status", status))
+				return nil, fmt.Errorf("invalid TENDER_REOPEN_STATUSES: %s", status)
+			}
+			reopenStatuses = append(reopenStatuses, model.TenderStatus(status))
+		}
+	}
+
+	closeInterval := time.Minute
+	if interval := os.Getenv("TENDER_CLOSE_INTERVAL"); interval != "" {
+		closeInterval, err = time.ParseDuration(interval)
+		if err != nil || closeInterval <= 0 {
+			slog.Error("TENDER_CLOSE_INTERVAL must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid TENDER_CLOSE_INTERVAL: %s", interval)
+		}
+	}
+
+	auctionExtension := 5 * time.Minute
+	if extension := os.Getenv("AUCTION_EXTENSION"); extension != "" {
+		auctionExtension, err = time.ParseDuration(extension)
+		if err != nil || auctionExtension <= 0 {
+			slog.Error("AUCTION_EXTENSION must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid AUCTION_EXTENSION: %s", extension)
+		}
+	}
+
+	attachmentsDir := os.Getenv("ATTACHMENTS_DIR")
+	if attachmentsDir == "" {
+		attachmentsDir = "./attachments"
+	}
+
+	attachmentMaxSize := int64(10 << 20)
+	if size := os.Getenv("ATTACHMENT_MAX_SIZE"); size != "" {
+		attachmentMaxSize, err = strconv.ParseInt(size, 10, 64)
+		if err != nil || attachmentMaxSize <= 0 {
+			slog.Error("ATTACHMENT_MAX_SIZE must be a positive number of bytes", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid ATTACHMENT_MAX_SIZE: %s", size)
+		}
+	}
+
+	attachmentTypes := []string{"application/pdf", "image/png", "image/jpeg", "application/zip", "text/plain"}
+	if types := os.Getenv("ATTACHMENT_TYPES"); types != "" {
+		attachmentTypes = nil
+		for _, contentType := range strings.Split(types, ",") {
+			attachmentTypes = append(attachmentTypes, strings.TrimSpace(contentType))
+		}
+	}
+
+	outboxRelayInterval := time.Second
+	if interval := os.Getenv("OUTBOX_RELAY_INTERVAL"); interval != "" {
+		outboxRelayInterval, err = time.ParseDuration(interval)
+		if err != nil || outboxRelayInterval <= 0 {
+			slog.Error("OUTBOX_RELAY_INTERVAL must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid OUTBOX_RELAY_INTERVAL: %s", interval)
+		}
+	}
+
+	webhookDispatchInterval := 5 * time.Second
+	if interval := os.Getenv("WEBHOOK_DISPATCH_INTERVAL"); interval != "" {
+		webhookDispatchInterval, err = time.ParseDuration(interval)
+		if err != nil || webhookDispatchInterval <= 0 {
+			slog.Error("WEBHOOK_DISPATCH_INTERVAL must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid WEBHOOK_DISPATCH_INTERVAL: %s", interval)
+		}
+	}
+
+	webhookTimeout := 10 * time.Second
+	if timeout := os.Getenv("WEBHOOK_TIMEOUT"); timeout != "" {
+		webhookTimeout, err = time.ParseDuration(timeout)
+		if err != nil || webhookTimeout <= 0 {
+			slog.Error("WEBHOOK_TIMEOUT must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid WEBHOOK_TIMEOUT: %s", timeout)
+		}
+	}
+
+	webhookMaxAttempts := 8
+	if attempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); attempts != "" {
+		webhookMaxAttempts, err = strconv.Atoi(attempts)
+		if err != nil || webhookMaxAttempts <= 0 {
+			slog.Error("WEBHOOK_MAX_ATTEMPTS must be a positive number", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS: %s", attempts)
+		}
+	}
+
+	webhookRetryBase := 30 * time.Second
+	if base := os.Getenv("WEBHOOK_RETRY_BASE"); base != "" {
+		webhookRetryBase, err = time.ParseDuration(base)
+		if err != nil || webhookRetryBase <= 0 {
+			slog.Error("WEBHOOK_RETRY_BASE must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid WEBHOOK_RETRY_BASE: %s", base)
+		}
+	}
+
+	eventsPollInterval := 5 * time.Second
+	if interval := os.Getenv("EVENTS_POLL_INTERVAL"); interval != "" {
+		eventsPollInterval, err = time.ParseDuration(interval)
+		if err != nil || eventsPollInterval <= 0 {
+			slog.Error("EVENTS_POLL_INTERVAL must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid EVENTS_POLL_INTERVAL: %s", interval)
+		}
+	}
+
+	emailSink := os.Getenv("EMAIL_SINK")
+	emailFrom := os.Getenv("EMAIL_FROM")
+	smtpHost := os.Getenv("SMTP_HOST")
+	switch emailSink {
+	case "":
+	case "smtp":
+		if smtpHost == "" || emailFrom == "" {
+			slog.Error("SMTP_HOST and EMAIL_FROM must be set for smtp email sink")
+			return nil, fmt.Errorf("missing smtp email settings")
+		}
+	case "file":
+		if emailFrom == "" {
+			emailFrom = "tender-api@localhost"
+		}
+	default:
+		slog.Error("EMAIL_SINK must be smtp, file or empty", slog.String("sink", emailSink))
+		return nil, fmt.Errorf("invalid EMAIL_SINK: %s", emailSink)
+	}
+
+	emailDir := os.Getenv("EMAIL_DIR")
+	if emailDir == "" {
+		emailDir = "./mail"
+	}
+
+	emailTimeout := 10 * time.Second
+	if timeout := os.Getenv("EMAIL_TIMEOUT"); timeout != "" {
+		emailTimeout, err = time.ParseDuration(timeout)
+		if err != nil || emailTimeout <= 0 {
+			slog.Error("EMAIL_TIMEOUT must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid EMAIL_TIMEOUT: %s", timeout)
+		}
+	}
+
+	emailDispatchInterval := 5 * time.Second
+	if interval := os.Getenv("EMAIL_DISPATCH_INTERVAL"); interval != "" {
+		emailDispatchInterval, err = time.ParseDuration(interval)
+		if err != nil || emailDispatchInterval <= 0 {
+			slog.Error("EMAIL_DISPATCH_INTERVAL must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid EMAIL_DISPATCH_INTERVAL: %s", interval)
+		}
+	}
+
+	emailMaxAttempts := 5
+	if attempts := os.Getenv("EMAIL_MAX_ATTEMPTS"); attempts != "" {
+		emailMaxAttempts, err = strconv.Atoi(attempts)
+		if err != nil || emailMaxAttempts <= 0 {
+			slog.Error("EMAIL_MAX_ATTEMPTS must be a positive number", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid EMAIL_MAX_ATTEMPTS: %s", attempts)
+		}
+	}
+
+	emailRetryBase := time.Minute
+	if base := os.Getenv("EMAIL_RETRY_BASE"); base != "" {
+		emailRetryBase, err = time.ParseDuration(base)
+		if err != nil || emailRetryBase <= 0 {
+			slog.Error("EMAIL_RETRY_BASE must be a positive duration", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid EMAIL_RETRY_BASE: %s", base)
+		}
+	}
+
+	smtpPort := 587
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		smtpPort, err = strconv.Atoi(port)
+		if err != nil || smtpPort <= 0 {
+			slog.Error("SMTP_PORT must be a positive number", slog.Any("error", err))
+			return nil, fmt.Errorf("invalid SMTP_PORT: %s", port)
+		}
+	}
+
 	return &Config{
-		DBConnStr: connStr,
-		Port:      port,
+		DBConnStr:               connStr,
+		Port:                    port,
+		JWTSecret:               jwtSecret,
+		JWTTTL:                  jwtTTL,
+		TenderReopenStatuses:    reopenStatuses,
+		TenderCloseInterval:     closeInterval,
+		AuctionExtension:        auctionExtension,
+		AttachmentsDir:          attachmentsDir,
+		AttachmentMaxSize:       attachmentMaxSize,
+		AttachmentTypes:         attachmentTypes,
+		OutboxRelayInterval:     outboxRelayInterval,
+		WebhookDispatchInterval: webhookDispatchInterval,
+		WebhookTimeout:          webhookTimeout,
+		WebhookMaxAttempts:      webhookMaxAttempts,
+		WebhookRetryBase:        webhookRetryBase,
+		EventsPollInterval:      eventsPollInterval,
+		EmailSink:               emailSink,
+		EmailFrom:               emailFrom,
+		EmailDir:                emailDir,
+		EmailTimeout:            emailTimeout,
+		SMTPHost:                smtpHost,
+		SMTPPort:                smtpPort,
+		SMTPUsername:            os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:            os.Getenv("SMTP_PASSWORD"),
+		EmailDispatchInterval:   emailDispatchInterval,
+		EmailMaxAttempts:        emailMaxAttempts,
+		EmailRetryBase:          emailRetryBase,
 	}, nil
 }
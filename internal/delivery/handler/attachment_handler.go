@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+	"mime"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type attachmentHandler struct {
+	attachmentService service.AttachmentService
+	logger            *slog.Logger
+}
+
+type AttachmentHandler interface {
+	UploadTenderAttachment(c *fiber.Ctx) error
+	GetTenderAttachments(c *fiber.Ctx) error
+	DownloadTenderAttachment(c *fiber.Ctx) error
+	DeleteTenderAttachment(c *fiber.Ctx) error
+	UploadBidAttachment(c *fiber.Ctx) error
+	GetBidAttachments(c *fiber.Ctx) error
+	DownloadBidAttachment(c *fiber.Ctx) error
+	DeleteBidAttachment(c *fiber.Ctx) error
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService, logger *slog.Logger) AttachmentHandler {
+	return &attachmentHandler{attachmentService: attachmentService, logger: logger}
+}
+
+func (h *attachmentHandler) UploadTenderAttachment(c *fiber.Ctx) error {
+	return h.upload(c, model.AttachmentOwnerTender, c.Params("tenderId"))
+}
+
+func (h *attachmentHandler) GetTenderAttachments(c *fiber.Ctx) error {
+	return h.list(c, model.AttachmentOwnerTender, c.Params("tenderId"))
+}
+
+func (h *attachmentHandler) DownloadTenderAttachment(c *fiber.Ctx) error {
+	return h.download(c, model.AttachmentOwnerTender, c.Params("tenderId"))
+}
+
+func (h *attachmentHandler) DeleteTenderAttachment(c *fiber.Ctx) error {
+	return h.delete(c, model.AttachmentOwnerTender, c.Params("tenderId"))
+}
+
+func (h *attachmentHandler) UploadBidAttachment(c *fiber.Ctx) error {
+	return h.upload(c, model.AttachmentOwnerBid, c.Params("bidId"))
+}
+
+func (h *attachmentHandler) GetBidAttachments(c *fiber.Ctx) error {
+	return h.list(c, model.AttachmentOwnerBid, c.Params("bidId"))
+}
+
+func (h *attachmentHandler) DownloadBidAttachment(c *fiber.Ctx) error {
+	return h.download(c, model.AttachmentOwnerBid, c.Params("bidId"))
+}
+
+func (h *attachmentHandler) DeleteBidAttachment(c *fiber.Ctx) error {
+	return h.delete(c, model.AttachmentOwnerBid, c.Params("bidId"))
+}
+
+func (h *attachmentHandler) upload(c *fiber.Ctx, ownerType model.AttachmentOwnerType, ownerID string) error {
+	ctx := c.Context()
+	uploadAttachmentRequest := new(model.UploadAttachmentRequest)
+	uploadAttachmentRequest.OwnerID = ownerID
+
+	if err := c.QueryParser(uploadAttachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &uploadAttachmentRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(uploadAttachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error reading uploaded file", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "File is required"})
+	}
+
+	fileName := filepath.Base(fileHeader.Filename)
+	if fileName == "." || fileName == string(filepath.Separator) || len(fileName) > 255 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid file name"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error opening uploaded file", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid file"})
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadAttachment(ctx, ownerType, ownerID, uploadAttachmentRequest.Username, model.AttachmentUpload{
+		FileName: fileName,
+		Size:     fileHeader.Size,
+		Content:  file,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error uploading attachment", slog.Any("error", err))
+		return h.attachmentError(c, err, "Error uploading attachment")
+	}
+	return c.Status(fiber.StatusCreated).JSON(attachment)
+}
+
+func (h *attachmentHandler) list(c *fiber.Ctx, ownerType model.AttachmentOwnerType, ownerID string) error {
+	ctx := c.Context()
+	getAttachmentsRequest := new(model.GetAttachmentsRequest)
+	getAttachmentsRequest.OwnerID = ownerID
+
+	if err := c.QueryParser(getAttachmentsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getAttachmentsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getAttachmentsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(ctx, ownerType, ownerID, getAttachmentsRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting attachments", slog.Any("error", err))
+		return h.attachmentError(c, err, "Error getting attachments")
+	}
+	return c.Status(fiber.StatusOK).JSON(attachments)
+}
+
+func (h *attachmentHandler) download(c *fiber.Ctx, ownerType model.AttachmentOwnerType, ownerID string) error {
+	ctx := c.Context()
+	attachmentRequest := new(model.AttachmentRequest)
+	attachmentRequest.OwnerID = ownerID
+	attachmentRequest.AttachmentID = c.Params("attachmentId")
+
+	if err := c.QueryParser(attachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &attachmentRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(attachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	attachment, file, err := h.attachmentService.DownloadAttachment(ctx, ownerType, ownerID, attachmentRequest.AttachmentID, attachmentRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error downloading attachment", slog.Any("error", err))
+		return h.attachmentError(c, err, "Error downloading attachment")
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Set("X-Checksum-SHA256", attachment.Checksum)
+	// Файл закрывается после отправки ответа
+	return c.Status(fiber.StatusOK).SendStream(file, int(attachment.Size))
+}
+
+func (h *attachmentHandler) delete(c *fiber.Ctx, ownerType model.AttachmentOwnerType, ownerID string) error {
+	ctx := c.Context()
+	attachmentRequest := new(model.AttachmentRequest)
+	attachmentRequest.OwnerID = ownerID
+	attachmentRequest.AttachmentID = c.Params("attachmentId")
+
+	if err := c.QueryParser(attachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &attachmentRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(attachmentRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	err := h.attachmentService.DeleteAttachment(ctx, ownerType, ownerID, attachmentRequest.AttachmentID, attachmentRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error deleting attachment", slog.Any("error", err))
+		return h.attachmentError(c, err, "Error deleting attachment")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *attachmentHandler) attachmentError(c *fiber.Ctx, err error, reason string) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrForbidden) {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderNotFound) || errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrAttachmentNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrAttachmentTooLarge) {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrAttachmentType) {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: reason})
+}
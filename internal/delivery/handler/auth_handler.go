@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils/middleware"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type authHandler struct {
+	authService service.AuthService
+	logger      *slog.Logger
+}
+
+type AuthHandler interface {
+	CreateToken(c *fiber.Ctx) error
+}
+
+func NewAuthHandler(authService service.AuthService, logger *slog.Logger) AuthHandler {
+	return &authHandler{authService: authService, logger: logger}
+}
+
+func (h *authHandler) CreateToken(c *fiber.Ctx) error {
+	ctx := c.Context()
+	createTokenRequest := new(model.CreateTokenRequest)
+
+	if err := c.BodyParser(createTokenRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := utils.ValidateStruct(createTokenRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	token, err := h.authService.CreateToken(ctx, createTokenRequest.Username, createTokenRequest.Password)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error creating token", slog.Any("error", err))
+		if errors.Is(err, model.ErrInvalidCredentials) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error creating token"})
+	}
+	return c.Status(fiber.StatusOK).JSON(token)
+}
+
+// Подставляет имя пользователя из токена, если оно не передано в запросе,
+// и запрещает действовать от имени другого пользователя
+func authorizeUsername(c *fiber.Ctx, username *string) error {
+	authUsername, ok := c.Locals(middleware.UsernameKey).(string)
+	if !ok || authUsername == "" {
+		return model.ErrUserNotFound
+	}
+
+	if *username == "" {
+		*username = authUsername
+		return nil
+	}
+
+	if *username != authUsername {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+func authorizeUsernameError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: "username does not match authenticated user"})
+}
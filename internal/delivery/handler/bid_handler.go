@@ -20,12 +20,21 @@ type BidHandler interface {
 	CreateBid(c *fiber.Ctx) error
 	GetCurrentUserBids(c *fiber.Ctx) error
 	GetTenderBids(c *fiber.Ctx) error
+	SearchBids(c *fiber.Ctx) error
 	GetBidStatus(c *fiber.Ctx) error
 	UpdateBidStatus(c *fiber.Ctx) error
 	EditBid(c *fiber.Ctx) error
 	SubmitBidDecision(c *fiber.Ctx) error
 	AddBidFeedback(c *fiber.Ctx) error
 	RollbackBidVersion(c *fiber.Ctx) error
+	GetBidReviews(c *fiber.Ctx) error
+	GetBidDecisions(c *fiber.Ctx) error
+	GetBidVersions(c *fiber.Ctx) error
+	GetBidVersion(c *fiber.Ctx) error
+	GetBidDiff(c *fiber.Ctx) error
+	SubmitBidScores(c *fiber.Ctx) error
+	GetBidScores(c *fiber.Ctx) error
+	PlaceAuctionPrice(c *fiber.Ctx) error
 }
 
 func NewBidHandler(bidService service.BidService, logger *slog.Logger) BidHandler {
@@ -41,6 +50,11 @@ func (h *bidHandler) CreateBid(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
 	}
 
+	if err := authorizeUsername(c, &createBidRequest.CreatorUsername); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	err = utils.ValidateStruct(createBidRequest)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
@@ -53,6 +67,18 @@ func (h *bidHandler) CreateBid(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotPublished) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrDeadlinePassed) || errors.Is(err, model.ErrAuctionClosed) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrBidOutOfBudget) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error creating bid"})
 	}
 	return c.Status(fiber.StatusCreated).JSON(bid)
@@ -68,12 +94,23 @@ func (h *bidHandler) GetCurrentUserBids(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getCurrentUserBidsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getCurrentUserBidsRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	bids, err := h.service.GetCurrentUserBids(ctx, getCurrentUserBidsRequest.Limit, getCurrentUserBidsRequest.Offset, getCurrentUserBidsRequest.Username)
+	cursor, err := model.DecodeCursor(getCurrentUserBidsRequest.Cursor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error decoding cursor", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bids, err := h.service.GetCurrentUserBids(ctx, getCurrentUserBidsRequest.Limit, getCurrentUserBidsRequest.Offset, cursor, getCurrentUserBidsRequest.Username)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -81,7 +118,9 @@ func (h *bidHandler) GetCurrentUserBids(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bids"})
 	}
-	return c.Status(fiber.StatusOK).JSON(bids)
+	return sendPage(c, bids, getCurrentUserBidsRequest.Limit, func(last *model.Bid) (string, string) {
+		return last.Name, last.ID
+	})
 }
 
 func (h *bidHandler) GetTenderBids(c *fiber.Ctx) error {
@@ -95,12 +134,27 @@ func (h *bidHandler) GetTenderBids(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getTenderBidsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getTenderBidsRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	bids, err := h.service.GetTenderBids(ctx, getTenderBidsRequest.TenderID, getTenderBidsRequest.Limit, getTenderBidsRequest.Offset, getTenderBidsRequest.Username)
+	sort := getTenderBidsRequest.BidSort()
+	cursor, err := model.DecodeCursor(getTenderBidsRequest.Cursor)
+	if err == nil {
+		err = sort.CheckCursor(cursor)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error decoding cursor", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bids, err := h.service.GetTenderBids(ctx, getTenderBidsRequest.TenderID, getTenderBidsRequest.Limit, getTenderBidsRequest.Offset, cursor, sort, getTenderBidsRequest.Username)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -114,7 +168,40 @@ func (h *bidHandler) GetTenderBids(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bids"})
 	}
-	return c.Status(fiber.StatusOK).JSON(bids)
+	return sendPage(c, bids, getTenderBidsRequest.Limit, func(last *model.Bid) (string, string) {
+		return sort.CursorValue(last), last.ID
+	})
+}
+
+func (h *bidHandler) SearchBids(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	searchBidsRequest := new(model.SearchBidsRequest)
+
+	if err := c.QueryParser(searchBidsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &searchBidsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(searchBidsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	results, err := h.service.SearchBids(ctx, searchBidsRequest.Query, searchBidsRequest.TenderID, searchBidsRequest.Limit, searchBidsRequest.Offset, searchBidsRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error searching bids", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error searching bids"})
+	}
+	return c.Status(fiber.StatusOK).JSON(results)
 }
 
 func (h *bidHandler) GetBidStatus(c *fiber.Ctx) error {
@@ -128,6 +215,11 @@ func (h *bidHandler) GetBidStatus(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getBidStatusRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getBidStatusRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
@@ -139,6 +231,12 @@ func (h *bidHandler) GetBidStatus(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid status"})
 	}
 	return c.Status(fiber.StatusOK).JSON(status)
@@ -155,12 +253,23 @@ func (h *bidHandler) UpdateBidStatus(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &updateBidStatusRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(updateBidStatusRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	status, err := h.service.UpdateBidStatus(ctx, updateBidStatusRequest.BidID, updateBidStatusRequest.Username, string(updateBidStatusRequest.Status))
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing If-Match header", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bid, err := h.service.UpdateBidStatus(ctx, updateBidStatusRequest.BidID, updateBidStatusRequest.Username, string(updateBidStatusRequest.Status), expectedVersion)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error updating bid status", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -172,9 +281,16 @@ func (h *bidHandler) UpdateBidStatus(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrBidNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrInvalidTransition) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error updating bid status"})
 	}
-	return c.Status(fiber.StatusOK).JSON(status)
+	setETag(c, bid.Version)
+	return c.Status(fiber.StatusOK).JSON(bid.Status)
 }
 
 func (h *bidHandler) EditBid(c *fiber.Ctx) error {
@@ -193,12 +309,23 @@ func (h *bidHandler) EditBid(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
 	}
 
+	if err := authorizeUsername(c, &editBidRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(editBidRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	bid, err := h.service.EditBid(ctx, editBidRequest.BidID, editBidRequest.Username, editBidRequest.UpdateData)
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing If-Match header", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bid, err := h.service.EditBid(ctx, editBidRequest.BidID, editBidRequest.Username, editBidRequest.UpdateData, expectedVersion)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error editing bid", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -207,11 +334,24 @@ func (h *bidHandler) EditBid(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrForbidden) {
 			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
-		if errors.Is(err, model.ErrBidNotFound) {
+		if errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrTenderNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrBidNotEditable) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrDeadlinePassed) || errors.Is(err, model.ErrAuctionPriceLocked) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrBidOutOfBudget) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error editing bid"})
 	}
+	setETag(c, bid.Version)
 	return c.Status(fiber.StatusOK).JSON(bid)
 }
 
@@ -227,6 +367,11 @@ func (h *bidHandler) RollbackBidVersion(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &rollbackBidRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(rollbackBidRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
@@ -247,8 +392,25 @@ func (h *bidHandler) RollbackBidVersion(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrForbidden) {
 			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotEditable) || errors.Is(err, model.ErrAuctionPriceLocked) || errors.Is(err, model.ErrDeadlinePassed) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrBidOutOfBudget) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error rolling back bid"})
 	}
 
+	setETag(c, bid.Version)
 	return c.Status(fiber.StatusOK).JSON(bid)
 }
 
@@ -263,6 +425,11 @@ func (h *bidHandler) SubmitBidDecision(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &submitBidDecisionRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(submitBidDecisionRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
@@ -283,6 +450,12 @@ func (h *bidHandler) SubmitBidDecision(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrDecisionSubmit) {
 			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrInvalidTransition) || errors.Is(err, model.ErrDecisionExists) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error submitting bid decision"})
 	}
 	return c.Status(fiber.StatusOK).JSON(bid)
@@ -299,12 +472,17 @@ func (h *bidHandler) AddBidFeedback(c *fiber.Ctx) error{
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 	
+	if err := authorizeUsername(c, &addBidFeedbackRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(addBidFeedbackRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	bid,err := h.service.AddBidFeedback(ctx, addBidFeedbackRequest.BidID, addBidFeedbackRequest.Username, addBidFeedbackRequest.Feedback)
+	review, err := h.service.AddBidFeedback(ctx, addBidFeedbackRequest.BidID, addBidFeedbackRequest.Username, addBidFeedbackRequest.Feedback)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error adding bid feedback", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -318,6 +496,341 @@ func (h *bidHandler) AddBidFeedback(c *fiber.Ctx) error{
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error adding bid feedback"})
 	}
+	return c.Status(fiber.StatusOK).JSON(review)
+
+}
+
+func (h *bidHandler) GetBidReviews(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidReviewsRequest := new(model.GetBidReviewsRequest)
+	getBidReviewsRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(getBidReviewsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidReviewsRequest.RequesterUsername); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidReviewsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	reviews, err := h.service.GetBidReviews(ctx, getBidReviewsRequest.TenderID, getBidReviewsRequest.AuthorUsername, getBidReviewsRequest.RequesterUsername, getBidReviewsRequest.Limit, getBidReviewsRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid reviews", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) || errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid reviews"})
+	}
+	return c.Status(fiber.StatusOK).JSON(reviews)
+}
+
+func (h *bidHandler) GetBidDecisions(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidDecisionsRequest := new(model.GetBidDecisionsRequest)
+	getBidDecisionsRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(getBidDecisionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidDecisionsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidDecisionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	decisions, err := h.service.GetBidDecisions(ctx, getBidDecisionsRequest.BidID, getBidDecisionsRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid decisions", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid decisions"})
+	}
+	return c.Status(fiber.StatusOK).JSON(decisions)
+}
+
+func (h *bidHandler) GetBidVersions(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidVersionsRequest := new(model.GetBidVersionsRequest)
+	getBidVersionsRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(getBidVersionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidVersionsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidVersionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	versions, err := h.service.GetBidVersions(ctx, getBidVersionsRequest.BidID, getBidVersionsRequest.Username, getBidVersionsRequest.Limit, getBidVersionsRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid versions", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid versions"})
+	}
+	return c.Status(fiber.StatusOK).JSON(versions)
+}
+
+func (h *bidHandler) GetBidVersion(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidVersionRequest := new(model.GetBidVersionRequest)
+	getBidVersionRequest.BidID = c.Params("bidId")
+
+	version, err := c.ParamsInt("version")
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing version parameter", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid version parameter"})
+	}
+	getBidVersionRequest.Version = version
+
+	if err := c.QueryParser(getBidVersionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidVersionRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidVersionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bid, err := h.service.GetBidVersion(ctx, getBidVersionRequest.BidID, getBidVersionRequest.Username, getBidVersionRequest.Version)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid version", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid version"})
+	}
 	return c.Status(fiber.StatusOK).JSON(bid)
+}
+
+func (h *bidHandler) GetBidDiff(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidDiffRequest := new(model.GetBidDiffRequest)
+	getBidDiffRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(getBidDiffRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidDiffRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidDiffRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	diff, err := h.service.GetBidDiff(ctx, getBidDiffRequest.BidID, getBidDiffRequest.Username, getBidDiffRequest.From, getBidDiffRequest.To)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid diff", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid diff"})
+	}
+	return c.Status(fiber.StatusOK).JSON(diff)
+}
+
+func (h *bidHandler) SubmitBidScores(c *fiber.Ctx) error {
+	ctx := c.Context()
+	submitBidScoresRequest := new(model.SubmitBidScoresRequest)
+
+	if err := c.BodyParser(submitBidScoresRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+	submitBidScoresRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(submitBidScoresRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &submitBidScoresRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
 
-}
\ No newline at end of file
+	if err := utils.ValidateStruct(submitBidScoresRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	scores, err := h.service.SubmitBidScores(ctx, submitBidScoresRequest.BidID, submitBidScoresRequest.Username, submitBidScoresRequest.Scores)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error submitting bid scores", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrCriterionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotScorable) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error submitting bid scores"})
+	}
+	return c.Status(fiber.StatusOK).JSON(scores)
+}
+
+func (h *bidHandler) GetBidScores(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getBidScoresRequest := new(model.GetBidScoresRequest)
+	getBidScoresRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(getBidScoresRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getBidScoresRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getBidScoresRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	scores, err := h.service.GetBidScores(ctx, getBidScoresRequest.BidID, getBidScoresRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting bid scores", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting bid scores"})
+	}
+	return c.Status(fiber.StatusOK).JSON(scores)
+}
+
+func (h *bidHandler) PlaceAuctionPrice(c *fiber.Ctx) error {
+	ctx := c.Context()
+	placeAuctionPriceRequest := new(model.PlaceAuctionPriceRequest)
+
+	if err := c.BodyParser(placeAuctionPriceRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+	placeAuctionPriceRequest.BidID = c.Params("bidId")
+
+	if err := c.QueryParser(placeAuctionPriceRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &placeAuctionPriceRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(placeAuctionPriceRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	bid, err := h.service.PlaceAuctionPrice(ctx, placeAuctionPriceRequest.BidID, placeAuctionPriceRequest.Username, placeAuctionPriceRequest.Price)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error placing auction price", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrNotAuction) || errors.Is(err, model.ErrAuctionClosed) || errors.Is(err, model.ErrAuctionPriceTooHigh) || errors.Is(err, model.ErrBidNotInAuction) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrBidOutOfBudget) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error placing auction price"})
+	}
+	setETag(c, bid.Version)
+	return c.Status(fiber.StatusOK).JSON(bid)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const nextCursorHeader = "X-Next-Cursor"
+
+// sendPage отдаёт страницу списка. Курсор следующей страницы есть только у полной страницы.
+// Клиент курсорной пагинации (передан параметр cursor, пустой - первая страница) получает {items, nextCursor},
+// остальные - прежний массив с курсором в заголовке X-Next-Cursor
+func sendPage[T any](c *fiber.Ctx, items []T, limit int, cursorOf func(*T) (string, string)) error {
+	var nextCursor *string
+	if len(items) > 0 && len(items) >= limit {
+		value, id := cursorOf(&items[len(items)-1])
+		encoded := model.EncodeCursor(value, id)
+		nextCursor = &encoded
+		c.Set(nextCursorHeader, encoded)
+	}
+
+	if !c.Context().QueryArgs().Has("cursor") {
+		return c.Status(fiber.StatusOK).JSON(items)
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return c.Status(fiber.StatusOK).JSON(model.Page[T]{Items: items, NextCursor: nextCursor})
+}
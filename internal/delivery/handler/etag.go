@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// ETag сущности - её версия в кавычках, например "3"
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// Ожидаемая версия из If-Match, 0 - если заголовок не передан
+func ifMatchVersion(c *fiber.Ctx) (int, error) {
+	value := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version < 1 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
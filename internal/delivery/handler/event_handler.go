@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type eventHandler struct {
+	eventService service.EventService
+	pollInterval time.Duration
+	logger       *slog.Logger
+}
+
+type EventHandler interface {
+	StreamTenderEvents(c *fiber.Ctx) error
+}
+
+func NewEventHandler(eventService service.EventService, pollInterval time.Duration, logger *slog.Logger) EventHandler {
+	return &eventHandler{eventService: eventService, pollInterval: pollInterval, logger: logger}
+}
+
+// StreamTenderEvents отдаёт события предложений тендера как server-sent events.
+// Клиент возобновляет поток с заголовком Last-Event-ID или параметром lastEventId - это номер публикации события
+func (h *eventHandler) StreamTenderEvents(c *fiber.Ctx) error {
+	ctx := c.Context()
+	streamRequest := new(model.StreamTenderEventsRequest)
+	streamRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(streamRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if lastEventID := c.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Error parsing Last-Event-ID", slog.Any("error", err))
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid Last-Event-ID header"})
+		}
+		streamRequest.LastEventID = id
+	}
+
+	if err := authorizeUsername(c, &streamRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(streamRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	if err := h.eventService.CheckTenderAccess(ctx, streamRequest.TenderID, streamRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Error checking tender access", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error streaming tender events"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	tenderID := streamRequest.TenderID
+	lastEventID := streamRequest.LastEventID
+	notify, unsubscribe := h.eventService.SubscribeTender(tenderID)
+
+	// Контекст запроса fiber переиспользуется после возврата из обработчика,
+	// поэтому поток работает со своим контекстом
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		streamCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ticker := time.NewTicker(h.pollInterval)
+		defer ticker.Stop()
+
+		heartbeat := false
+		for {
+			events, cursor, err := h.eventService.GetTenderEvents(streamCtx, tenderID, lastEventID)
+			if err != nil {
+				h.logger.ErrorContext(streamCtx, "Error getting tender events", slog.Any("error", err))
+				return
+			}
+
+			for _, event := range events {
+				data, err := json.Marshal(event)
+				if err != nil {
+					h.logger.ErrorContext(streamCtx, "Error encoding tender event", slog.Any("error", err))
+					return
+				}
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+			}
+			if len(events) == 0 && heartbeat {
+				// Комментарий держит соединение открытым и выявляет отключившихся клиентов
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+			heartbeat = false
+
+			// Пока курсор сдвигается, события дочитываются без ожидания
+			if cursor != lastEventID {
+				lastEventID = cursor
+				continue
+			}
+
+			select {
+			case _, ok := <-notify:
+				if !ok {
+					return
+				}
+			case <-ticker.C:
+				heartbeat = true
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type notificationHandler struct {
+	notificationService service.NotificationService
+	logger              *slog.Logger
+}
+
+type NotificationHandler interface {
+	GetNotifications(c *fiber.Ctx) error
+	GetUnreadNotifications(c *fiber.Ctx) error
+	MarkNotificationRead(c *fiber.Ctx) error
+	MarkAllNotificationsRead(c *fiber.Ctx) error
+}
+
+func NewNotificationHandler(notificationService service.NotificationService, logger *slog.Logger) NotificationHandler {
+	return &notificationHandler{notificationService: notificationService, logger: logger}
+}
+
+func (h *notificationHandler) GetNotifications(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getNotificationsRequest := new(model.GetNotificationsRequest)
+
+	if err := c.QueryParser(getNotificationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getNotificationsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getNotificationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	notifications, err := h.notificationService.GetNotifications(ctx, getNotificationsRequest.Username, getNotificationsRequest.Unread, getNotificationsRequest.Limit, getNotificationsRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting notifications", slog.Any("error", err))
+		return h.notificationError(c, err, "Error getting notifications")
+	}
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+func (h *notificationHandler) GetUnreadNotifications(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getUnreadRequest := new(model.GetUnreadNotificationsRequest)
+
+	if err := c.QueryParser(getUnreadRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getUnreadRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getUnreadRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	unread, err := h.notificationService.CountUnreadNotifications(ctx, getUnreadRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error counting unread notifications", slog.Any("error", err))
+		return h.notificationError(c, err, "Error counting unread notifications")
+	}
+	return c.Status(fiber.StatusOK).JSON(model.UnreadNotificationsResponse{Unread: unread})
+}
+
+func (h *notificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	ctx := c.Context()
+	notificationRequest := new(model.NotificationRequest)
+	notificationRequest.NotificationID = c.Params("notificationId")
+
+	if err := c.QueryParser(notificationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &notificationRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(notificationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	notification, err := h.notificationService.MarkNotificationRead(ctx, notificationRequest.NotificationID, notificationRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error marking notification read", slog.Any("error", err))
+		return h.notificationError(c, err, "Error marking notification read")
+	}
+	return c.Status(fiber.StatusOK).JSON(notification)
+}
+
+func (h *notificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	ctx := c.Context()
+	markAllRequest := new(model.MarkAllNotificationsReadRequest)
+
+	if err := c.QueryParser(markAllRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &markAllRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(markAllRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	marked, err := h.notificationService.MarkAllNotificationsRead(ctx, markAllRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error marking notifications read", slog.Any("error", err))
+		return h.notificationError(c, err, "Error marking notifications read")
+	}
+	return c.Status(fiber.StatusOK).JSON(model.MarkNotificationsReadResponse{Marked: marked})
+}
+
+func (h *notificationHandler) notificationError(c *fiber.Ctx, err error, reason string) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrNotificationNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: reason})
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type organizationHandler struct {
+	organizationService service.OrganizationService
+	logger              *slog.Logger
+}
+
+type OrganizationHandler interface {
+	CreateOrganization(c *fiber.Ctx) error
+	GetOrganizations(c *fiber.Ctx) error
+	GetOrganization(c *fiber.Ctx) error
+	EditOrganization(c *fiber.Ctx) error
+	AddOrganizationResponsible(c *fiber.Ctx) error
+	RemoveOrganizationResponsible(c *fiber.Ctx) error
+}
+
+func NewOrganizationHandler(organizationService service.OrganizationService, logger *slog.Logger) OrganizationHandler {
+	return &organizationHandler{organizationService: organizationService, logger: logger}
+}
+
+func (h *organizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	ctx := c.Context()
+	createOrganizationRequest := new(model.CreateOrganizationRequest)
+
+	if err := c.BodyParser(createOrganizationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := authorizeUsername(c, &createOrganizationRequest.CreatorUsername); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(createOrganizationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	organization, err := h.organizationService.CreateOrganization(ctx, createOrganizationRequest)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error creating organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error creating organization"})
+	}
+	return c.Status(fiber.StatusOK).JSON(organization)
+}
+
+func (h *organizationHandler) GetOrganizations(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getOrganizationsRequest := new(model.GetOrganizationsRequest)
+
+	if err := c.QueryParser(getOrganizationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := utils.ValidateStruct(getOrganizationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	organizations, err := h.organizationService.GetOrganizations(ctx, getOrganizationsRequest.Limit, getOrganizationsRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting organizations", slog.Any("error", err))
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting organizations"})
+	}
+	return c.Status(fiber.StatusOK).JSON(organizations)
+}
+
+func (h *organizationHandler) GetOrganization(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getOrganizationRequest := new(model.GetOrganizationRequest)
+	getOrganizationRequest.OrganizationID = c.Params("organizationId")
+
+	if err := utils.ValidateStruct(getOrganizationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	organization, err := h.organizationService.GetOrganizationById(ctx, getOrganizationRequest.OrganizationID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrOrganizationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting organization"})
+	}
+	return c.Status(fiber.StatusOK).JSON(organization)
+}
+
+func (h *organizationHandler) EditOrganization(c *fiber.Ctx) error {
+	ctx := c.Context()
+	editOrganizationRequest := new(model.EditOrganizationRequest)
+	editOrganizationRequest.OrganizationID = c.Params("organizationId")
+
+	if err := c.QueryParser(editOrganizationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := c.BodyParser(&editOrganizationRequest.UpdateData); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := authorizeUsername(c, &editOrganizationRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(editOrganizationRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	organization, err := h.organizationService.EditOrganization(ctx, editOrganizationRequest.OrganizationID, editOrganizationRequest.Username, editOrganizationRequest.UpdateData)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error editing organization", slog.Any("error", err))
+		return h.organizationError(c, err, "Error editing organization")
+	}
+	return c.Status(fiber.StatusOK).JSON(organization)
+}
+
+func (h *organizationHandler) AddOrganizationResponsible(c *fiber.Ctx) error {
+	ctx := c.Context()
+	responsibleRequest := new(model.OrganizationResponsibleRequest)
+	responsibleRequest.OrganizationID = c.Params("organizationId")
+	responsibleRequest.ResponsibleUsername = c.Params("responsibleUsername")
+
+	if err := c.QueryParser(responsibleRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &responsibleRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(responsibleRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	responsible, err := h.organizationService.AddOrganizationResponsible(ctx, responsibleRequest.OrganizationID, responsibleRequest.Username, responsibleRequest.ResponsibleUsername)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error adding organization responsible", slog.Any("error", err))
+		return h.organizationError(c, err, "Error adding organization responsible")
+	}
+	return c.Status(fiber.StatusOK).JSON(responsible)
+}
+
+func (h *organizationHandler) RemoveOrganizationResponsible(c *fiber.Ctx) error {
+	ctx := c.Context()
+	responsibleRequest := new(model.OrganizationResponsibleRequest)
+	responsibleRequest.OrganizationID = c.Params("organizationId")
+	responsibleRequest.ResponsibleUsername = c.Params("responsibleUsername")
+
+	if err := c.QueryParser(responsibleRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &responsibleRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(responsibleRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	err := h.organizationService.RemoveOrganizationResponsible(ctx, responsibleRequest.OrganizationID, responsibleRequest.Username, responsibleRequest.ResponsibleUsername)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error removing organization responsible", slog.Any("error", err))
+		return h.organizationError(c, err, "Error removing organization responsible")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *organizationHandler) organizationError(c *fiber.Ctx, err error, reason string) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrForbidden) {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrOrganizationNotFound) || errors.Is(err, model.ErrResponsibleNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrResponsibleExists) || errors.Is(err, model.ErrLastResponsible) {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: reason})
+}
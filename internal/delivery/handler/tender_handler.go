@@ -25,6 +25,12 @@ type TenderHandler interface {
 	UpdateTenderStatus(c *fiber.Ctx) error
 	EditTender(c *fiber.Ctx) error
 	RollbackTender(c *fiber.Ctx) error
+	GetTenderVersions(c *fiber.Ctx) error
+	GetTenderVersion(c *fiber.Ctx) error
+	GetTenderDiff(c *fiber.Ctx) error
+	SetTenderCriteria(c *fiber.Ctx) error
+	GetTenderCriteria(c *fiber.Ctx) error
+	GetAuction(c *fiber.Ctx) error
 }
 
 func NewTenderHandler(tenderService service.TenderService, logger *slog.Logger) TenderHandler {
@@ -40,6 +46,11 @@ func (h *tenderHandler) CreateTender(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
 	}
 
+	if err := authorizeUsername(c, &createTenderRequest.CreatorUsername); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(createTenderRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
@@ -51,6 +62,9 @@ func (h *tenderHandler) CreateTender(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrInvalidBudget) || errors.Is(err, model.ErrInvalidDeadline) || errors.Is(err, model.ErrInvalidAuction) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error creating tender"})
 	}
 	return c.Status(fiber.StatusOK).JSON(tender)
@@ -67,17 +81,48 @@ func (h *tenderHandler) GetTenders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getTendersRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getTendersRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	tenders, err := h.tenderService.GetTenders(ctx, getTendersRequest.Limit, getTendersRequest.Offset, getTendersRequest.ServiceTypes)
+	filter, err := getTendersRequest.Filter()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing tender filter", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	cursor, err := model.DecodeCursor(getTendersRequest.Cursor)
+	if err == nil {
+		err = filter.CheckCursor(cursor)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error decoding cursor", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	if getTendersRequest.Query != "" {
+		results, err := h.tenderService.SearchTenders(ctx, getTendersRequest.Query, getTendersRequest.Limit, getTendersRequest.Offset, filter, getTendersRequest.Username)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Error searching tenders", slog.Any("error", err))
+			return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error searching tenders"})
+		}
+		return c.Status(fiber.StatusOK).JSON(results)
+	}
+
+	tenders, err := h.tenderService.GetTenders(ctx, getTendersRequest.Limit, getTendersRequest.Offset, cursor, filter, getTendersRequest.Username)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tenders"})
 	}
-	return c.Status(fiber.StatusOK).JSON(tenders)
+	return sendPage(c, tenders, getTendersRequest.Limit, func(last *model.Tender) (string, string) {
+		return filter.CursorValue(last), last.ID
+	})
 }
 
 func (h *tenderHandler) GetCurrentUserTenders(c *fiber.Ctx) error {
@@ -90,12 +135,23 @@ func (h *tenderHandler) GetCurrentUserTenders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getCurrentUserTendersRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getCurrentUserTendersRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	tenders, err := h.tenderService.GetCurrentUserTenders(ctx, getCurrentUserTendersRequest.Limit, getCurrentUserTendersRequest.Offset, getCurrentUserTendersRequest.Username)
+	cursor, err := model.DecodeCursor(getCurrentUserTendersRequest.Cursor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error decoding cursor", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	tenders, err := h.tenderService.GetCurrentUserTenders(ctx, getCurrentUserTendersRequest.Limit, getCurrentUserTendersRequest.Offset, cursor, getCurrentUserTendersRequest.Username)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -103,7 +159,9 @@ func (h *tenderHandler) GetCurrentUserTenders(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tenders"})
 	}
-	return c.Status(fiber.StatusOK).JSON(tenders)
+	return sendPage(c, tenders, getCurrentUserTendersRequest.Limit, func(last *model.Tender) (string, string) {
+		return last.Name, last.ID
+	})
 
 }
 
@@ -118,17 +176,28 @@ func (h *tenderHandler) GetTenderStatus(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &getTenderStatusRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(getTenderStatusRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	status, err := h.tenderService.GetTenderStatus(ctx, getTenderStatusRequest.TenderID)
+	status, err := h.tenderService.GetTenderStatus(ctx, getTenderStatusRequest.TenderID, getTenderStatusRequest.Username)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error getting tender status", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tender status"})
 	}
 	return c.Status(fiber.StatusOK).JSON(status)
@@ -144,12 +213,23 @@ func (h *tenderHandler) UpdateTenderStatus(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &updateTenderStatusRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(updateTenderStatusRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	tender, err := h.tenderService.UpdateTenderStatus(ctx, updateTenderStatusRequest.TenderID, updateTenderStatusRequest.Username, string(updateTenderStatusRequest.Status))
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing If-Match header", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	tender, err := h.tenderService.UpdateTenderStatus(ctx, updateTenderStatusRequest.TenderID, updateTenderStatusRequest.Username, string(updateTenderStatusRequest.Status), expectedVersion)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Error updating tender status", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -161,8 +241,15 @@ func (h *tenderHandler) UpdateTenderStatus(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrTenderNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrInvalidTransition) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error updating tender status"})
 	}
+	setETag(c, tender.Version)
 	return c.Status(fiber.StatusOK).JSON(tender)
 
 }
@@ -185,20 +272,44 @@ func (h *tenderHandler) EditTender(c *fiber.Ctx) error {
 
 	fmt.Println(editTenderRequest.UpdateData)
 	fmt.Println(editTenderRequest)
+	if err := authorizeUsername(c, &editTenderRequest.Username); err != nil {
+		h.logger.Error("Authorization error", "error", err)
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(editTenderRequest); err != nil {
 		h.logger.Error("Validation error", "error", err)
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
 	}
 
-	updatedTender, err := h.tenderService.EditTender(ctx, editTenderRequest.TenderID, editTenderRequest.Username, editTenderRequest.UpdateData)
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing If-Match header", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	updatedTender, err := h.tenderService.EditTender(ctx, editTenderRequest.TenderID, editTenderRequest.Username, editTenderRequest.UpdateData, expectedVersion)
 	if err != nil {
 		h.logger.Error("Error updating tender", "error", err)
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrInvalidBudget) || errors.Is(err, model.ErrInvalidDeadline) || errors.Is(err, model.ErrInvalidAuction) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error updating tender"})
 	}
 
+	setETag(c, updatedTender.Version)
 	return c.Status(fiber.StatusOK).JSON(updatedTender)
 }
 
@@ -214,6 +325,11 @@ func (h *tenderHandler) RollbackTender(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
 	}
 
+	if err := authorizeUsername(c, &rollbackTenderRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
 	if err := utils.ValidateStruct(rollbackTenderRequest); err != nil {
 		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
@@ -225,7 +341,7 @@ func (h *tenderHandler) RollbackTender(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid version parameter"})
 	}
 
-	tender, err := h.tenderService.RollbackTenderVersion(ctx, rollbackTenderRequest.TenderID, version)
+	tender, err := h.tenderService.RollbackTenderVersion(ctx, rollbackTenderRequest.TenderID, rollbackTenderRequest.Username, version)
 	if err != nil {
 		h.logger.Error("Error rolling back tender", "error", err)
 		if errors.Is(err, model.ErrUserNotFound) {
@@ -237,7 +353,257 @@ func (h *tenderHandler) RollbackTender(c *fiber.Ctx) error {
 		if errors.Is(err, model.ErrTenderNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
 		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrInvalidBudget) || errors.Is(err, model.ErrInvalidDeadline) || errors.Is(err, model.ErrInvalidAuction) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error rolling back tender"})
 	}
+	setETag(c, tender.Version)
 	return c.Status(fiber.StatusOK).JSON(tender)
 }
+
+func (h *tenderHandler) GetTenderVersions(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getTenderVersionsRequest := new(model.GetTenderVersionsRequest)
+	getTenderVersionsRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(getTenderVersionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getTenderVersionsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getTenderVersionsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	versions, err := h.tenderService.GetTenderVersions(ctx, getTenderVersionsRequest.TenderID, getTenderVersionsRequest.Username, getTenderVersionsRequest.Limit, getTenderVersionsRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting tender versions", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tender versions"})
+	}
+	return c.Status(fiber.StatusOK).JSON(versions)
+}
+
+func (h *tenderHandler) GetTenderVersion(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getTenderVersionRequest := new(model.GetTenderVersionRequest)
+	getTenderVersionRequest.TenderID = c.Params("tenderId")
+
+	version, err := c.ParamsInt("version")
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing version parameter", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid version parameter"})
+	}
+	getTenderVersionRequest.Version = version
+
+	if err := c.QueryParser(getTenderVersionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getTenderVersionRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getTenderVersionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	tender, err := h.tenderService.GetTenderVersion(ctx, getTenderVersionRequest.TenderID, getTenderVersionRequest.Username, getTenderVersionRequest.Version)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting tender version", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tender version"})
+	}
+	return c.Status(fiber.StatusOK).JSON(tender)
+}
+
+func (h *tenderHandler) GetTenderDiff(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getTenderDiffRequest := new(model.GetTenderDiffRequest)
+	getTenderDiffRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(getTenderDiffRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getTenderDiffRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getTenderDiffRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	diff, err := h.tenderService.GetTenderDiff(ctx, getTenderDiffRequest.TenderID, getTenderDiffRequest.Username, getTenderDiffRequest.From, getTenderDiffRequest.To)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting tender diff", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tender diff"})
+	}
+	return c.Status(fiber.StatusOK).JSON(diff)
+}
+
+func (h *tenderHandler) SetTenderCriteria(c *fiber.Ctx) error {
+	ctx := c.Context()
+	setTenderCriteriaRequest := new(model.SetTenderCriteriaRequest)
+
+	if err := c.BodyParser(setTenderCriteriaRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+	setTenderCriteriaRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(setTenderCriteriaRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &setTenderCriteriaRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(setTenderCriteriaRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	criteria, err := h.tenderService.SetTenderCriteria(ctx, setTenderCriteriaRequest.TenderID, setTenderCriteriaRequest.Username, setTenderCriteriaRequest.Criteria)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error setting tender criteria", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error setting tender criteria"})
+	}
+	return c.Status(fiber.StatusOK).JSON(criteria)
+}
+
+func (h *tenderHandler) GetTenderCriteria(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getTenderCriteriaRequest := new(model.GetTenderCriteriaRequest)
+	getTenderCriteriaRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(getTenderCriteriaRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getTenderCriteriaRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getTenderCriteriaRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	criteria, err := h.tenderService.GetTenderCriteria(ctx, getTenderCriteriaRequest.TenderID, getTenderCriteriaRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting tender criteria", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting tender criteria"})
+	}
+	return c.Status(fiber.StatusOK).JSON(criteria)
+}
+
+func (h *tenderHandler) GetAuction(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getAuctionRequest := new(model.GetAuctionRequest)
+	getAuctionRequest.TenderID = c.Params("tenderId")
+
+	if err := c.QueryParser(getAuctionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getAuctionRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getAuctionRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	auction, err := h.tenderService.GetAuction(ctx, getAuctionRequest.TenderID, getAuctionRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting auction", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderNotFound) || errors.Is(err, model.ErrNotAuction) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting auction"})
+	}
+	return c.Status(fiber.StatusOK).JSON(auction)
+}
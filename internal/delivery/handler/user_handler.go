@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils/middleware"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type userHandler struct {
+	userService service.UserService
+	logger      *slog.Logger
+}
+
+type UserHandler interface {
+	CreateUser(c *fiber.Ctx) error
+	GetUsers(c *fiber.Ctx) error
+	GetUser(c *fiber.Ctx) error
+	EditUser(c *fiber.Ctx) error
+	GetCurrentUserOrganizations(c *fiber.Ctx) error
+}
+
+func NewUserHandler(userService service.UserService, logger *slog.Logger) UserHandler {
+	return &userHandler{userService: userService, logger: logger}
+}
+
+func (h *userHandler) CreateUser(c *fiber.Ctx) error {
+	ctx := c.Context()
+	createUserRequest := new(model.CreateUserRequest)
+
+	if err := c.BodyParser(createUserRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := utils.ValidateStruct(createUserRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	user, err := h.userService.CreateUser(ctx, createUserRequest)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error creating user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserExists) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error creating user"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(model.NewUserProfile(user))
+}
+
+func (h *userHandler) GetUsers(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getUsersRequest := new(model.GetUsersRequest)
+
+	if err := c.QueryParser(getUsersRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := utils.ValidateStruct(getUsersRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	users, err := h.userService.GetUsers(ctx, getUsersRequest.Limit, getUsersRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting users", slog.Any("error", err))
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting users"})
+	}
+	return c.Status(fiber.StatusOK).JSON(users)
+}
+
+func (h *userHandler) GetUser(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getUserRequest := new(model.GetUserRequest)
+	getUserRequest.Username = c.Params("username")
+
+	if err := utils.ValidateStruct(getUserRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	user, err := h.userService.GetUserByUsername(ctx, getUserRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting user"})
+	}
+
+	// Адрес и настройки писем видит только сам сотрудник
+	if authUsername, ok := c.Locals(middleware.UsernameKey).(string); ok && authUsername == user.Username {
+		return c.Status(fiber.StatusOK).JSON(model.NewUserProfile(user))
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+func (h *userHandler) EditUser(c *fiber.Ctx) error {
+	ctx := c.Context()
+	editUserRequest := new(model.EditUserRequest)
+	editUserRequest.Username = c.Params("username")
+
+	if err := c.BodyParser(&editUserRequest.UpdateData); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := authorizeUsername(c, &editUserRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(editUserRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	user, err := h.userService.EditUser(ctx, editUserRequest.Username, editUserRequest.UpdateData)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error editing user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error editing user"})
+	}
+	return c.Status(fiber.StatusOK).JSON(model.NewUserProfile(user))
+}
+
+func (h *userHandler) GetCurrentUserOrganizations(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getCurrentUserOrganizationsRequest := new(model.GetCurrentUserOrganizationsRequest)
+
+	if err := c.QueryParser(getCurrentUserOrganizationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getCurrentUserOrganizationsRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getCurrentUserOrganizationsRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	organizations, err := h.userService.GetCurrentUserOrganizations(ctx, getCurrentUserOrganizationsRequest.Limit, getCurrentUserOrganizationsRequest.Offset, getCurrentUserOrganizationsRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting user organizations", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: "Error getting user organizations"})
+	}
+	return c.Status(fiber.StatusOK).JSON(organizations)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/service"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type webhookHandler struct {
+	webhookService service.WebhookService
+	logger         *slog.Logger
+}
+
+type WebhookHandler interface {
+	CreateWebhook(c *fiber.Ctx) error
+	GetWebhooks(c *fiber.Ctx) error
+	EditWebhook(c *fiber.Ctx) error
+	DeleteWebhook(c *fiber.Ctx) error
+	GetWebhookDeliveries(c *fiber.Ctx) error
+	RedeliverWebhook(c *fiber.Ctx) error
+}
+
+func NewWebhookHandler(webhookService service.WebhookService, logger *slog.Logger) WebhookHandler {
+	return &webhookHandler{webhookService: webhookService, logger: logger}
+}
+
+func (h *webhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	ctx := c.Context()
+	createWebhookRequest := new(model.CreateWebhookRequest)
+	createWebhookRequest.OrganizationID = c.Params("organizationId")
+
+	if err := c.QueryParser(createWebhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := c.BodyParser(createWebhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := authorizeUsername(c, &createWebhookRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(createWebhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(ctx, createWebhookRequest)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error creating webhook", slog.Any("error", err))
+		return h.webhookError(c, err, "Error creating webhook")
+	}
+	return c.Status(fiber.StatusOK).JSON(webhook)
+}
+
+func (h *webhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getWebhooksRequest := new(model.GetWebhooksRequest)
+	getWebhooksRequest.OrganizationID = c.Params("organizationId")
+
+	if err := c.QueryParser(getWebhooksRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getWebhooksRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getWebhooksRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	webhooks, err := h.webhookService.GetWebhooks(ctx, getWebhooksRequest.OrganizationID, getWebhooksRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting webhooks", slog.Any("error", err))
+		return h.webhookError(c, err, "Error getting webhooks")
+	}
+	return c.Status(fiber.StatusOK).JSON(webhooks)
+}
+
+func (h *webhookHandler) EditWebhook(c *fiber.Ctx) error {
+	ctx := c.Context()
+	editWebhookRequest := new(model.EditWebhookRequest)
+	editWebhookRequest.OrganizationID = c.Params("organizationId")
+	editWebhookRequest.WebhookID = c.Params("webhookId")
+
+	if err := c.QueryParser(editWebhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := c.BodyParser(&editWebhookRequest.UpdateData); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing request body", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid request body"})
+	}
+
+	if err := authorizeUsername(c, &editWebhookRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(editWebhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	webhook, err := h.webhookService.EditWebhook(ctx, editWebhookRequest.OrganizationID, editWebhookRequest.WebhookID, editWebhookRequest.Username, editWebhookRequest.UpdateData)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error editing webhook", slog.Any("error", err))
+		return h.webhookError(c, err, "Error editing webhook")
+	}
+	return c.Status(fiber.StatusOK).JSON(webhook)
+}
+
+func (h *webhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	ctx := c.Context()
+	webhookRequest := new(model.WebhookRequest)
+	webhookRequest.OrganizationID = c.Params("organizationId")
+	webhookRequest.WebhookID = c.Params("webhookId")
+
+	if err := c.QueryParser(webhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &webhookRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(webhookRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	err := h.webhookService.DeleteWebhook(ctx, webhookRequest.OrganizationID, webhookRequest.WebhookID, webhookRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error deleting webhook", slog.Any("error", err))
+		return h.webhookError(c, err, "Error deleting webhook")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *webhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	ctx := c.Context()
+	getDeliveriesRequest := new(model.GetWebhookDeliveriesRequest)
+	getDeliveriesRequest.OrganizationID = c.Params("organizationId")
+	getDeliveriesRequest.WebhookID = c.Params("webhookId")
+
+	if err := c.QueryParser(getDeliveriesRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &getDeliveriesRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(getDeliveriesRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	deliveries, err := h.webhookService.GetWebhookDeliveries(ctx, getDeliveriesRequest.OrganizationID, getDeliveriesRequest.WebhookID, getDeliveriesRequest.Username, getDeliveriesRequest.Limit, getDeliveriesRequest.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error getting webhook deliveries", slog.Any("error", err))
+		return h.webhookError(c, err, "Error getting webhook deliveries")
+	}
+	return c.Status(fiber.StatusOK).JSON(deliveries)
+}
+
+func (h *webhookHandler) RedeliverWebhook(c *fiber.Ctx) error {
+	ctx := c.Context()
+	redeliverRequest := new(model.RedeliverWebhookRequest)
+	redeliverRequest.OrganizationID = c.Params("organizationId")
+	redeliverRequest.WebhookID = c.Params("webhookId")
+	redeliverRequest.DeliveryID = c.Params("deliveryId")
+
+	if err := c.QueryParser(redeliverRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Error parsing query parameters", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: "Invalid query parameters"})
+	}
+
+	if err := authorizeUsername(c, &redeliverRequest.Username); err != nil {
+		h.logger.ErrorContext(ctx, "Authorization error", slog.Any("error", err))
+		return authorizeUsernameError(c, err)
+	}
+
+	if err := utils.ValidateStruct(redeliverRequest); err != nil {
+		h.logger.ErrorContext(ctx, "Validation error", slog.Any("error", err))
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+
+	delivery, err := h.webhookService.RedeliverWebhook(ctx, redeliverRequest.OrganizationID, redeliverRequest.WebhookID, redeliverRequest.DeliveryID, redeliverRequest.Username)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Error redelivering webhook", slog.Any("error", err))
+		return h.webhookError(c, err, "Error redelivering webhook")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(delivery)
+}
+
+func (h *webhookHandler) webhookError(c *fiber.Ctx, err error, reason string) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrForbidden) {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	if errors.Is(err, model.ErrOrganizationNotFound) || errors.Is(err, model.ErrWebhookNotFound) || errors.Is(err, model.ErrDeliveryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{Reason: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{Reason: reason})
+}
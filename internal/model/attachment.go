@@ -0,0 +1,33 @@
+package model
+
+import (
+	"io"
+	"time"
+)
+
+type AttachmentOwnerType string
+
+const (
+	AttachmentOwnerTender AttachmentOwnerType = "Tender"
+	AttachmentOwnerBid    AttachmentOwnerType = "Bid"
+)
+
+type Attachment struct {
+	ID          string              `json:"id"`
+	OwnerType   AttachmentOwnerType `json:"ownerType"`
+	OwnerID     string              `json:"ownerId"`
+	FileName    string              `json:"fileName"`
+	ContentType string              `json:"contentType"`
+	Size        int64               `json:"size"`
+	// SHA-256 содержимого в hex, позволяет проверить файл после скачивания
+	Checksum   string    `json:"checksum"`
+	StorageKey string    `json:"-"`
+	CreatedBy  string    `json:"createdBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
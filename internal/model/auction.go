@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// Состояние редукциона для участников: видна только текущая минимальная цена, без автора предложения
+type Auction struct {
+	TenderID    string         `json:"tenderId"`
+	EndsAt      time.Time      `json:"endsAt"`
+	LowestPrice *Decimal       `json:"lowestPrice,omitempty"`
+	Currency    *string        `json:"currency,omitempty"`
+	BidsCount   int            `json:"bidsCount"`
+	Result      *AuctionResult `json:"result,omitempty"`
+}
+
+// Итог редукциона: предложение с минимальной ценой предлагается ответственным для SubmitBidDecision
+type AuctionResult struct {
+	TenderID   string    `json:"tenderId"`
+	BidID      *string   `json:"bidId,omitempty"`
+	Price      *Decimal  `json:"price,omitempty"`
+	Currency   *string   `json:"currency,omitempty"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// Редукцион проводится только для поставок, цены сравниваются в валюте бюджета.
+// Торги должны завершиться до дедлайна, иначе тендер закроется раньше выбора победителя
+func (t *Tender) CheckAuction() error {
+	if !t.Auction {
+		if t.AuctionEndsAt != nil {
+			return ErrInvalidAuction
+		}
+		return nil
+	}
+	if t.ServiceType != TenderServiceTypeDelivery || t.BudgetCurrency == nil || t.AuctionEndsAt == nil {
+		return ErrInvalidAuction
+	}
+	if t.IsExpired(*t.AuctionEndsAt) {
+		return ErrInvalidAuction
+	}
+	return nil
+}
+
+func (t *Tender) IsAuctionOpen(now time.Time) bool {
+	return t.Auction && t.AuctionEndsAt != nil && now.Before(*t.AuctionEndsAt)
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 
 type BidStatus string
@@ -27,10 +30,12 @@ const (
 type CreateBidRequest struct {
 	Name          string      `json:"name" validate:"required,max=255"` 
 	Description   string      `json:"description" validate:"required,max=1000"` 
-	Status        BidStatus   `json:"status" validate:"required,bidstatus"`    
+	Status        BidStatus   `json:"status" validate:"required,oneof=Created Published"`    
 	TenderID      string      `json:"tenderId" validate:"required"`         
 	OrganizationID string      `json:"organizationId,omitempty" validate:"omitempty"` 
 	CreatorUsername string      `json:"creatorUsername" validate:"required"`     
+	Price         *Decimal    `json:"price" validate:"required_with=Currency,omitempty,amount"`
+	Currency      *string     `json:"currency" validate:"required_with=Price,omitempty,iso4217"`
 }
 
 
@@ -43,7 +48,46 @@ type Bid struct {
 	AuthorType    BidAuthorType `json:"authorType"`
 	AuthorID      string        `json:"authorId"`
 	CreatorUsername string        `json:"creatorUsername"`
+	Price         *Decimal      `json:"price,omitempty"`
+	Currency      *string       `json:"currency,omitempty"`
+	// Взвешенная оценка, заполняется только в списке предложений тендера
+	Score         *float64      `json:"score,omitempty"`
 	Version       int           `json:"version"`
 	CreatedAt     time.Time     `json:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// Кто инициирует смену статуса предложения
+type BidActor string
+
+const (
+	BidActorAuthor      BidActor = "Author"
+	BidActorResponsible BidActor = "Responsible"
+)
+
+// Автор только публикует и отменяет, Approved/Rejected выставляются решением по тендеру
+var bidStatusTransitions = map[BidActor]map[BidStatus][]BidStatus{
+	BidActorAuthor: {
+		BidStatusCreated:   {BidStatusPublished, BidStatusCanceled},
+		BidStatusPublished: {BidStatusCanceled},
+	},
+	BidActorResponsible: {
+		BidStatusPublished: {BidStatusApproved, BidStatusRejected},
+	},
+}
+
+func CheckBidTransition(actor BidActor, from BidStatus, to BidStatus) error {
+	if slices.Contains(bidStatusTransitions[actor][from], to) {
+		return nil
+	}
+	return &TransitionError{From: string(from), To: string(to)}
+}
+
+func (s BidStatus) IsTerminal() bool {
+	return s == BidStatusCanceled || s == BidStatusApproved || s == BidStatusRejected
+}
+
+// Ответственные за тендер видят предложение только после публикации
+func (s BidStatus) IsVisibleToTenderResponsibles() bool {
+	return s == BidStatusPublished || s == BidStatusApproved || s == BidStatusRejected
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type BidDecisionRecord struct {
+	ID        string      `json:"id"`
+	BidID     string      `json:"bidId"`
+	Username  string      `json:"username"`
+	Decision  BidDecision `json:"decision"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// Кворум = min(MaxApprovalQuorum, количество ответственных за организацию)
+const MaxApprovalQuorum = 3
+
+func HasApprovalQuorum(approvals int, responsibles int) bool {
+	return approvals >= min(MaxApprovalQuorum, responsibles)
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type BidReview struct {
+	ID             string    `json:"id"`
+	BidID          string    `json:"bidId"`
+	AuthorUsername string    `json:"authorUsername"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
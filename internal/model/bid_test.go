@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBidTransition(t *testing.T) {
+	statuses := []BidStatus{BidStatusCreated, BidStatusPublished, BidStatusCanceled, BidStatusApproved, BidStatusRejected}
+
+	// Все разрешённые переходы; остальные пары статусов для актора запрещены
+	allowed := map[BidActor]map[BidStatus][]BidStatus{
+		BidActorAuthor: {
+			BidStatusCreated:   {BidStatusPublished, BidStatusCanceled},
+			BidStatusPublished: {BidStatusCanceled},
+		},
+		BidActorResponsible: {
+			BidStatusPublished: {BidStatusApproved, BidStatusRejected},
+		},
+	}
+
+	for _, actor := range []BidActor{BidActorAuthor, BidActorResponsible} {
+		for _, from := range statuses {
+			for _, to := range statuses {
+				want := false
+				for _, status := range allowed[actor][from] {
+					want = want || status == to
+				}
+
+				t.Run(string(actor)+" "+string(from)+" to "+string(to), func(t *testing.T) {
+					err := CheckBidTransition(actor, from, to)
+					if want {
+						assert.NoError(t, err)
+						return
+					}
+					assert.ErrorIs(t, err, ErrInvalidTransition)
+				})
+			}
+		}
+	}
+}
+
+func TestCheckBidTransitionUnknownActor(t *testing.T) {
+	assert.ErrorIs(t, CheckBidTransition(BidActor("Admin"), BidStatusPublished, BidStatusApproved), ErrInvalidTransition)
+}
+
+func TestBidStatusIsTerminal(t *testing.T) {
+	tests := []struct {
+		status   BidStatus
+		terminal bool
+	}{
+		{BidStatusCreated, false},
+		{BidStatusPublished, false},
+		{BidStatusCanceled, true},
+		{BidStatusApproved, true},
+		{BidStatusRejected, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.terminal, tt.status.IsTerminal())
+		})
+	}
+}
+
+func TestTerminalBidStatusHasNoTransitions(t *testing.T) {
+	statuses := []BidStatus{BidStatusCreated, BidStatusPublished, BidStatusCanceled, BidStatusApproved, BidStatusRejected}
+	for _, from := range statuses {
+		if !from.IsTerminal() {
+			continue
+		}
+		for _, actor := range []BidActor{BidActorAuthor, BidActorResponsible} {
+			for _, to := range statuses {
+				assert.Error(t, CheckBidTransition(actor, from, to), "%s %s -> %s", actor, from, to)
+			}
+		}
+	}
+}
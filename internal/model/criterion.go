@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Критерий оценки предложений, вес задаёт его долю в итоговом балле
+type TenderCriterion struct {
+	ID        string    `json:"id"`
+	TenderID  string    `json:"tenderId"`
+	Name      string    `json:"name"`
+	Weight    int       `json:"weight"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type BidScore struct {
+	ID          string    `json:"id"`
+	BidID       string    `json:"bidId"`
+	CriterionID string    `json:"criterionId"`
+	Username    string    `json:"username"`
+	Score       int       `json:"score"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
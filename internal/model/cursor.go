@@ -0,0 +1,41 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor - позиция в списке: значение поля сортировки и id последнего элемента
+type Cursor struct {
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+func EncodeCursor(value string, id string) string {
+	data, _ := json.Marshal(Cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Пустая строка означает первую страницу
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Page - страница списка при курсорной пагинации; NextCursor равен null на последней странице
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"nextCursor"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type ErrorResponse struct {
 	Reason string `json:"reason"`
@@ -8,11 +11,55 @@ type ErrorResponse struct {
 
 var (
 	ErrUserNotFound         = errors.New("user not found")
+	ErrUserExists           = errors.New("user already exists")
+	ErrInvalidCredentials   = errors.New("invalid username or password")
 	ErrForbidden            = errors.New("forbidden")
 	ErrTenderNotFound       = errors.New("tender not found")
 	ErrBidNotFound          = errors.New("bid not found")
 	ErrOrganizationNotFound = errors.New("organization not found")
 	ErrVersionNotFound      = errors.New("version not found")
 	ErrDecisionSubmit       = errors.New("decision cannot be submitted")
+	ErrDecisionExists       = errors.New("decision has already been submitted")
 	ErrFeedbackSubmit       = errors.New("feedback cannot be submitted")
+	ErrResponsibleNotFound  = errors.New("organization responsible not found")
+	ErrResponsibleExists    = errors.New("user is already responsible for organization")
+	ErrLastResponsible      = errors.New("organization must have at least one responsible")
+	ErrInvalidTransition    = errors.New("invalid status transition")
+	ErrTenderNotPublished   = errors.New("tender is not published")
+	ErrBidNotEditable       = errors.New("bid in terminal status cannot be edited")
+	ErrVersionConflict      = errors.New("entity version has changed")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidDateRange     = errors.New("date range start is after its end")
+	ErrInvalidBudget        = errors.New("invalid tender budget range or currency")
+	ErrCurrencyMismatch     = errors.New("bid currency does not match tender budget currency")
+	ErrBidOutOfBudget       = errors.New("bid price is outside of tender budget")
+	ErrDeadlinePassed       = errors.New("tender submission deadline has passed")
+	ErrInvalidDeadline      = errors.New("tender deadline must be in the future")
+	ErrCriterionNotFound    = errors.New("criterion not found")
+	ErrBidNotScorable       = errors.New("only published bids can be scored")
+	ErrInvalidAuction       = errors.New("auction requires a Delivery tender with budget currency and a future end time")
+	ErrNotAuction           = errors.New("tender is not an auction")
+	ErrAuctionClosed        = errors.New("auction is closed")
+	ErrAuctionPriceTooHigh  = errors.New("price must be lower than the current lowest price")
+	ErrAuctionPriceLocked   = errors.New("auction bid price can only be lowered by placing a new price")
+	ErrBidNotInAuction      = errors.New("only published bids take part in the auction")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrAttachmentTooLarge   = errors.New("attachment exceeds maximum size")
+	ErrAttachmentType       = errors.New("attachment type is not allowed")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrNotificationNotFound = errors.New("notification not found")
 )
+
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invalid status transition from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
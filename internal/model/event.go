@@ -0,0 +1,110 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTenderCreated   EventType = "TenderCreated"
+	EventTenderUpdated   EventType = "TenderUpdated"
+	EventTenderPublished EventType = "TenderPublished"
+	EventTenderClosed    EventType = "TenderClosed"
+	EventBidCreated      EventType = "BidCreated"
+	EventBidUpdated      EventType = "BidUpdated"
+	EventBidSubmitted    EventType = "BidSubmitted"
+	EventBidCanceled     EventType = "BidCanceled"
+	EventBidApproved     EventType = "BidApproved"
+	EventBidRejected     EventType = "BidRejected"
+)
+
+type EventAggregateType string
+
+const (
+	EventAggregateTender EventAggregateType = "Tender"
+	EventAggregateBid    EventAggregateType = "Bid"
+)
+
+// Доменное событие из outbox: ID растёт монотонно и задаёт порядок доставки
+type Event struct {
+	ID            int64              `json:"id"`
+	Type          EventType          `json:"type"`
+	AggregateType EventAggregateType `json:"aggregateType"`
+	AggregateID   string             `json:"aggregateId"`
+	TenderID      string             `json:"tenderId"`
+	Payload       json.RawMessage    `json:"payload"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	// Номер публикации в порядке фиксации, по нему возобновляется поток событий тендера
+	Sequence int64 `json:"-"`
+}
+
+// NewTenderEvent выбирает тип события по смене статуса, previous пустой при создании
+func NewTenderEvent(previous TenderStatus, tender *Tender) (*Event, error) {
+	eventType := EventTenderUpdated
+	switch {
+	case previous == "":
+		eventType = EventTenderCreated
+	case previous != tender.Status && tender.Status == TenderStatusPublished:
+		eventType = EventTenderPublished
+	case previous != tender.Status && tender.Status == TenderStatusClosed:
+		eventType = EventTenderClosed
+	}
+	return newEvent(eventType, EventAggregateTender, tender.ID, tender.ID, tender)
+}
+
+// NewBidEvent выбирает тип события по смене статуса, previous пустой при создании
+func NewBidEvent(previous BidStatus, bid *Bid) (*Event, error) {
+	eventType := EventBidUpdated
+	if previous == "" {
+		eventType = EventBidCreated
+	}
+	if previous != bid.Status {
+		switch bid.Status {
+		case BidStatusPublished:
+			eventType = EventBidSubmitted
+		case BidStatusCanceled:
+			eventType = EventBidCanceled
+		case BidStatusApproved:
+			eventType = EventBidApproved
+		case BidStatusRejected:
+			eventType = EventBidRejected
+		}
+	}
+	return newEvent(eventType, EventAggregateBid, bid.ID, bid.TenderID, bid)
+}
+
+// Предложения видны ответственным за тендер только после публикации, как и в API
+var tenderVisibleBidStatuses = []BidStatus{BidStatusPublished, BidStatusApproved, BidStatusRejected}
+
+// VisibleToTender сообщает, можно ли показать событие ответственным за тендер
+func (e *Event) VisibleToTender() (bool, error) {
+	if e.AggregateType != EventAggregateBid {
+		return true, nil
+	}
+	var bid struct {
+		Status BidStatus `json:"status"`
+	}
+	if err := json.Unmarshal(e.Payload, &bid); err != nil {
+		return false, fmt.Errorf("failed to decode bid event payload: %w", err)
+	}
+	return slices.Contains(tenderVisibleBidStatuses, bid.Status), nil
+}
+
+func newEvent(eventType EventType, aggregateType EventAggregateType, aggregateID string, tenderID string, payload any) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	return &Event{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		TenderID:      tenderID,
+		Payload:       data,
+		CreatedAt:     time.Now(),
+	}, nil
+}
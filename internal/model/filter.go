@@ -0,0 +1,158 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+type TenderSortField string
+
+const (
+	TenderSortName      TenderSortField = "name"
+	TenderSortCreatedAt TenderSortField = "created_at"
+	TenderSortUpdatedAt TenderSortField = "updated_at"
+)
+
+// Фильтры и сортировка списка тендеров, пустые поля не ограничивают выборку
+type TenderFilter struct {
+	ServiceTypes    []TenderServiceType
+	Statuses        []TenderStatus
+	OrganizationID  string
+	CreatorUsername string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	UpdatedFrom     *time.Time
+	UpdatedTo       *time.Time
+	Sort            TenderSortField
+	Desc            bool
+}
+
+func (r *GetTendersRequest) Filter() (TenderFilter, error) {
+	filter := TenderFilter{
+		ServiceTypes:    r.ServiceTypes,
+		Statuses:        r.Statuses,
+		OrganizationID:  r.OrganizationID,
+		CreatorUsername: r.CreatorUsername,
+		Sort:            TenderSortField(r.Sort),
+		Desc:            r.Order == "desc",
+	}
+	if filter.Sort == "" {
+		filter.Sort = TenderSortName
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseFilterTime(r.CreatedFrom); err != nil {
+		return TenderFilter{}, err
+	}
+	if filter.CreatedTo, err = parseFilterTime(r.CreatedTo); err != nil {
+		return TenderFilter{}, err
+	}
+	if filter.UpdatedFrom, err = parseFilterTime(r.UpdatedFrom); err != nil {
+		return TenderFilter{}, err
+	}
+	if filter.UpdatedTo, err = parseFilterTime(r.UpdatedTo); err != nil {
+		return TenderFilter{}, err
+	}
+
+	if isAfter(filter.CreatedFrom, filter.CreatedTo) || isAfter(filter.UpdatedFrom, filter.UpdatedTo) {
+		return TenderFilter{}, ErrInvalidDateRange
+	}
+	return filter, nil
+}
+
+// Значение поля сортировки тендера, по которому строится курсор
+func (f TenderFilter) CursorValue(tender *Tender) string {
+	switch f.Sort {
+	case TenderSortCreatedAt:
+		return tender.CreatedAt.Format(time.RFC3339Nano)
+	case TenderSortUpdatedAt:
+		return tender.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		return tender.Name
+	}
+}
+
+// Курсор от другой сортировки не подходит к текущей
+func (f TenderFilter) CheckCursor(cursor *Cursor) error {
+	if cursor == nil || f.Sort == TenderSortName {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func parseFilterTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func isAfter(from *time.Time, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
+
+type BidSortField string
+
+const (
+	BidSortName  BidSortField = "name"
+	BidSortPrice BidSortField = "price"
+	BidSortScore BidSortField = "score"
+)
+
+type BidSort struct {
+	Field BidSortField
+	Desc  bool
+}
+
+func (r *GetTenderBidsRequest) BidSort() BidSort {
+	sort := BidSort{Field: BidSortField(r.Sort), Desc: r.Order == "desc"}
+	if sort.Field == "" {
+		sort.Field = BidSortName
+	}
+	return sort
+}
+
+// Предложения без цены или оценки идут в конце списка при любом направлении сортировки
+func (s BidSort) MissingValue() string {
+	if s.Desc {
+		return "-Infinity"
+	}
+	return "Infinity"
+}
+
+func (s BidSort) CursorValue(bid *Bid) string {
+	switch s.Field {
+	case BidSortPrice:
+		if bid.Price == nil {
+			return s.MissingValue()
+		}
+		return string(*bid.Price)
+	case BidSortScore:
+		if bid.Score == nil {
+			return s.MissingValue()
+		}
+		return strconv.FormatFloat(*bid.Score, 'f', -1, 64)
+	default:
+		return bid.Name
+	}
+}
+
+func (s BidSort) CheckCursor(cursor *Cursor) error {
+	if cursor == nil || s.Field == BidSortName || cursor.Value == s.MissingValue() {
+		return nil
+	}
+	if s.Field == BidSortPrice && !Decimal(cursor.Value).IsValid() {
+		return ErrInvalidCursor
+	}
+	if _, err := strconv.ParseFloat(cursor.Value, 64); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
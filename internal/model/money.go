@@ -0,0 +1,75 @@
+package model
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Decimal - денежная сумма в десятичной записи, в БД хранится как NUMERIC(15, 2)
+type Decimal string
+
+var decimalRegexp = regexp.MustCompile(`^\d{1,13}(\.\d{1,2})?$`)
+
+func (d Decimal) IsValid() bool {
+	return decimalRegexp.MatchString(string(d))
+}
+
+// В JSON сумма отдаётся числом, чтобы не терять точность при разборе
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// Принимается и число, и строка, формат проверяет валидатор amount
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	*d = Decimal(strings.Trim(string(data), `"`))
+	return nil
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, _ := new(big.Rat).SetString(string(d))
+	b, _ := new(big.Rat).SetString(string(other))
+	if a == nil || b == nil {
+		return strings.Compare(string(d), string(other))
+	}
+	return a.Cmp(b)
+}
+
+func (t *Tender) HasBudget() bool {
+	return t.BudgetMin != nil || t.BudgetMax != nil
+}
+
+// Бюджет задаётся вместе с валютой, нижняя граница не больше верхней
+func (t *Tender) CheckBudget() error {
+	if t.HasBudget() && t.BudgetCurrency == nil {
+		return ErrInvalidBudget
+	}
+	if t.BudgetMin != nil && t.BudgetMax != nil && t.BudgetMin.Cmp(*t.BudgetMax) > 0 {
+		return ErrInvalidBudget
+	}
+	return nil
+}
+
+// Предложение без цены допустимо, с ценой - должно быть в валюте тендера и попадать в бюджет.
+// Валюта проверяется и без границ бюджета: цены редукциона сравниваются между собой
+func (t *Tender) CheckBidPrice(price *Decimal, currency *string) error {
+	if price == nil {
+		return nil
+	}
+	if currency == nil {
+		return ErrCurrencyMismatch
+	}
+	if t.BudgetCurrency != nil && *currency != *t.BudgetCurrency {
+		return ErrCurrencyMismatch
+	}
+	if !t.HasBudget() {
+		return nil
+	}
+	if t.BudgetMin != nil && price.Cmp(*t.BudgetMin) < 0 {
+		return ErrBidOutOfBudget
+	}
+	if t.BudgetMax != nil && price.Cmp(*t.BudgetMax) > 0 {
+		return ErrBidOutOfBudget
+	}
+	return nil
+}
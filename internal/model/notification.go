@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationBidApproved  NotificationType = "BidApproved"
+	NotificationBidRejected  NotificationType = "BidRejected"
+	NotificationBidFeedback  NotificationType = "BidFeedback"
+	NotificationTenderClosed NotificationType = "TenderClosed"
+)
+
+// Уведомление сотрудника о решении и отзыве по его предложению или о закрытии тендера, в котором он участвует
+type Notification struct {
+	ID        string           `json:"id"`
+	Username  string           `json:"username"`
+	Type      NotificationType `json:"type"`
+	TenderID  string           `json:"tenderId"`
+	BidID     *string          `json:"bidId,omitempty"`
+	Message   string           `json:"message"`
+	ReadAt    *time.Time       `json:"readAt,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	// Письмо, которое ставится в очередь вместе с уведомлением
+	Email *NotificationEmail `json:"-"`
+}
+
+type NotificationEmailStatus string
+
+const (
+	NotificationEmailPending NotificationEmailStatus = "Pending"
+	NotificationEmailSent    NotificationEmailStatus = "Sent"
+	// У сотрудника нет адреса или письма отключены
+	NotificationEmailSkipped NotificationEmailStatus = "Skipped"
+	NotificationEmailFailed  NotificationEmailStatus = "Failed"
+)
+
+// Письмо к уведомлению: адрес и язык берутся у сотрудника в момент отправки
+type NotificationEmail struct {
+	NotificationID string
+	Feedback       string
+	Status         NotificationEmailStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      *string
+	SentAt         *time.Time
+}
+
+// Письмо, взятое в работу, вместе с данными уведомления для шаблона
+type NotificationEmailDispatch struct {
+	NotificationEmail
+	Username string
+	Type     NotificationType
+	TenderID string
+	BidName  string
+}
+
+type UnreadNotificationsResponse struct {
+	Unread int `json:"unread"`
+}
+
+type MarkNotificationsReadResponse struct {
+	Marked int `json:"marked"`
+}
@@ -1,13 +1,17 @@
 package model
 
+import "time"
+
 type GetCurrentUserTendersRequest struct {
 	Limit    int    `query:"limit" validate:"min=1,max=100"`
 	Offset   int    `query:"offset" validate:"min=0"`
+	Cursor   string `query:"cursor"`
 	Username string `query:"username" validate:"required"`
 }
 
 type GetTenderStatusRequest struct {
 	TenderID string `params:"tenderId" validate:"required"`
+	Username string `query:"username" validate:"required"`
 }
 
 type UpdateTenderStatusRequest struct {
@@ -25,6 +29,7 @@ type EditTenderRequest struct {
 type RollbackTenderRequest struct {
 	TenderID string `params:"tenderId" validate:"required"`
 	Version  string `params:"version" validate:"required,number,min=1"`
+	Username string `query:"username" validate:"required"`
 }
 
 type CreateTenderRequest struct {
@@ -33,18 +38,42 @@ type CreateTenderRequest struct {
 	ServiceType     TenderServiceType `json:"serviceType" validate:"required,servicetype"`
 	OrganizationID  string            `json:"organizationId" validate:"required"`
 	CreatorUsername string            `json:"creatorUsername" validate:"required"`
+	BudgetMin       *Decimal          `json:"budgetMin" validate:"omitempty,amount"`
+	BudgetMax       *Decimal          `json:"budgetMax" validate:"omitempty,amount"`
+	BudgetCurrency  *string           `json:"budgetCurrency" validate:"required_with=BudgetMin BudgetMax,omitempty,iso4217"`
+	Deadline        *time.Time        `json:"deadline"`
+	Auction         bool              `json:"auction"`
+	AuctionEndsAt   *time.Time        `json:"auctionEndsAt" validate:"required_if=Auction true,excluded_unless=Auction true"`
 }
 
 type GetTendersRequest struct {
-	Limit        int                 `query:"limit" validate:"min=1,max=100"`
-	Offset       int                 `query:"offset" validate:"min=0"`
-	ServiceTypes []TenderServiceType `query:"service_type" validate:"dive,servicetype"`
+	Limit           int                 `query:"limit" validate:"min=1,max=100"`
+	Offset          int                 `query:"offset" validate:"min=0"`
+	Cursor          string              `query:"cursor" validate:"excluded_with=Query"`
+	Query           string              `query:"q" validate:"max=200"`
+	ServiceTypes    []TenderServiceType `query:"service_type" validate:"dive,servicetype"`
+	Statuses        []TenderStatus      `query:"status" validate:"dive,tenderstatus"`
+	OrganizationID  string              `query:"organizationId"`
+	CreatorUsername string              `query:"creatorUsername"`
+	CreatedFrom     string              `query:"createdFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	CreatedTo       string              `query:"createdTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom     string              `query:"updatedFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedTo       string              `query:"updatedTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Sort            string              `query:"sort" validate:"omitempty,oneof=name created_at updated_at"`
+	Order           string              `query:"order" validate:"omitempty,oneof=asc desc"`
+	Username        string              `query:"username" validate:"required"`
 }
 
 type UpdateData struct {
-	Name        *string `json:"name" validate:"omitempty,max=100"`
-	Description *string `json:"description" validate:"omitempty,max=1000"`
-	ServiceType *string `json:"serviceType" validate:"omitempty,servicetype"`
+	Name           *string    `json:"name" validate:"omitempty,max=100"`
+	Description    *string    `json:"description" validate:"omitempty,max=1000"`
+	ServiceType    *string    `json:"serviceType" validate:"omitempty,servicetype"`
+	BudgetMin      *Decimal   `json:"budgetMin" validate:"omitempty,amount"`
+	BudgetMax      *Decimal   `json:"budgetMax" validate:"omitempty,amount"`
+	BudgetCurrency *string    `json:"budgetCurrency" validate:"omitempty,iso4217"`
+	Deadline       *time.Time `json:"deadline"`
+	Price          *Decimal   `json:"price" validate:"omitempty,amount"`
+	Currency       *string    `json:"currency" validate:"omitempty,iso4217"`
 }
 
 type BidDecision string
@@ -57,6 +86,7 @@ const (
 type GetCurrentUserBidsRequest struct {
 	Limit    int    `query:"limit" validate:"min=1,max=100"`
 	Offset   int    `query:"offset" validate:"min=0"`
+	Cursor   string `query:"cursor"`
 	Username string `query:"username" validate:"required"`
 }
 
@@ -64,6 +94,9 @@ type GetTenderBidsRequest struct {
 	TenderID string `params:"tenderId" validate:"required"`
 	Limit    int    `query:"limit" validate:"min=1,max=100"`
 	Offset   int    `query:"offset" validate:"min=0"`
+	Cursor   string `query:"cursor"`
+	Sort     string `query:"sort" validate:"omitempty,oneof=name price score"`
+	Order    string `query:"order" validate:"omitempty,oneof=asc desc"`
 	Username string `query:"username" validate:"required"`
 }
 
@@ -96,8 +129,274 @@ type SubmitBidDecisionRequest struct {
 	Decision BidDecision `query:"decision" validate:"required,oneof=Approved Rejected"`
 }
 
+type PlaceAuctionPriceRequest struct {
+	BidID    string  `params:"bidId" validate:"required"`
+	Username string  `query:"username" validate:"required"`
+	Price    Decimal `json:"price" validate:"required,amount"`
+}
+
+type GetAuctionRequest struct {
+	TenderID string `params:"tenderId" validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type UploadAttachmentRequest struct {
+	OwnerID  string `validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetAttachmentsRequest struct {
+	OwnerID  string `validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type AttachmentRequest struct {
+	OwnerID      string `validate:"required"`
+	AttachmentID string `params:"attachmentId" validate:"required"`
+	Username     string `query:"username" validate:"required"`
+}
+
 type AddBidFeedbackRequest struct {
 	BidID    string `params:"bidId" validate:"required"`
 	Username string `query:"username" validate:"required"`
 	Feedback string `query:"feedback" validate:"required,max=1000"`
 }
+
+type GetBidReviewsRequest struct {
+	TenderID          string `params:"tenderId" validate:"required"`
+	AuthorUsername    string `query:"authorUsername" validate:"required"`
+	RequesterUsername string `query:"requesterUsername" validate:"required"`
+	Limit             int    `query:"limit" validate:"min=1,max=100"`
+	Offset            int    `query:"offset" validate:"min=0"`
+}
+
+type GetBidDecisionsRequest struct {
+	BidID    string `params:"bidId" validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type CriterionInput struct {
+	Name   string `json:"name" validate:"required,max=100"`
+	Weight int    `json:"weight" validate:"min=1,max=100"`
+}
+
+type SetTenderCriteriaRequest struct {
+	TenderID string           `params:"tenderId" validate:"required"`
+	Username string           `query:"username" validate:"required"`
+	Criteria []CriterionInput `json:"criteria" validate:"required,min=1,max=20,unique=Name,dive"`
+}
+
+type GetTenderCriteriaRequest struct {
+	TenderID string `params:"tenderId" validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type ScoreInput struct {
+	CriterionID string `json:"criterionId" validate:"required"`
+	Score       int    `json:"score" validate:"min=0,max=10"`
+}
+
+type SubmitBidScoresRequest struct {
+	BidID    string       `params:"bidId" validate:"required"`
+	Username string       `query:"username" validate:"required"`
+	Scores   []ScoreInput `json:"scores" validate:"required,min=1,unique=CriterionID,dive"`
+}
+
+type GetBidScoresRequest struct {
+	BidID    string `params:"bidId" validate:"required"`
+	Username string `query:"username" validate:"required"`
+}
+
+type CreateOrganizationRequest struct {
+	Name            string           `json:"name" validate:"required,max=100"`
+	Description     string           `json:"description" validate:"max=1000"`
+	Type            OrganizationType `json:"type" validate:"required,organizationtype"`
+	CreatorUsername string           `json:"creatorUsername" validate:"required"`
+}
+
+type GetOrganizationsRequest struct {
+	Limit  int `query:"limit" validate:"min=1,max=100"`
+	Offset int `query:"offset" validate:"min=0"`
+}
+
+type GetOrganizationRequest struct {
+	OrganizationID string `params:"organizationId" validate:"required"`
+}
+
+type EditOrganizationRequest struct {
+	OrganizationID string                 `params:"organizationId" validate:"required"`
+	Username       string                 `query:"username" validate:"required"`
+	UpdateData     UpdateOrganizationData `json:"updateData" validate:"required"`
+}
+
+type UpdateOrganizationData struct {
+	Name        *string `json:"name" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Type        *string `json:"type" validate:"omitempty,organizationtype"`
+}
+
+type OrganizationResponsibleRequest struct {
+	OrganizationID      string `params:"organizationId" validate:"required"`
+	ResponsibleUsername string `params:"responsibleUsername" validate:"required"`
+	Username            string `query:"username" validate:"required"`
+}
+
+type CreateWebhookRequest struct {
+	OrganizationID string      `params:"organizationId" validate:"required"`
+	Username       string      `query:"username" validate:"required"`
+	URL            string      `json:"url" validate:"required,https_url,max=2048"`
+	Secret         string      `json:"secret" validate:"required,min=16,max=255"`
+	EventTypes     []EventType `json:"eventTypes" validate:"required,min=1,unique,dive,eventtype"`
+}
+
+type EditWebhookRequest struct {
+	OrganizationID string            `params:"organizationId" validate:"required"`
+	WebhookID      string            `params:"webhookId" validate:"required"`
+	Username       string            `query:"username" validate:"required"`
+	UpdateData     UpdateWebhookData `json:"updateData" validate:"required"`
+}
+
+type UpdateWebhookData struct {
+	URL        *string     `json:"url" validate:"omitempty,https_url,max=2048"`
+	Secret     *string     `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []EventType `json:"eventTypes" validate:"omitempty,min=1,unique,dive,eventtype"`
+}
+
+type GetWebhooksRequest struct {
+	OrganizationID string `params:"organizationId" validate:"required"`
+	Username       string `query:"username" validate:"required"`
+}
+
+type WebhookRequest struct {
+	OrganizationID string `params:"organizationId" validate:"required"`
+	WebhookID      string `params:"webhookId" validate:"required"`
+	Username       string `query:"username" validate:"required"`
+}
+
+type GetWebhookDeliveriesRequest struct {
+	OrganizationID string `params:"organizationId" validate:"required"`
+	WebhookID      string `params:"webhookId" validate:"required"`
+	Limit          int    `query:"limit" validate:"min=1,max=100"`
+	Offset         int    `query:"offset" validate:"min=0"`
+	Username       string `query:"username" validate:"required"`
+}
+
+type RedeliverWebhookRequest struct {
+	OrganizationID string `params:"organizationId" validate:"required"`
+	WebhookID      string `params:"webhookId" validate:"required"`
+	DeliveryID     string `params:"deliveryId" validate:"required"`
+	Username       string `query:"username" validate:"required"`
+}
+
+type CreateUserRequest struct {
+	Username  string `json:"username" validate:"required,username"`
+	Password  string `json:"password" validate:"required,min=8,max=72"`
+	FirstName string `json:"first_name" validate:"max=50"`
+	LastName  string `json:"last_name" validate:"max=50"`
+	Email     string `json:"email" validate:"omitempty,email,max=254"`
+	Language  string `json:"language" validate:"omitempty,oneof=ru en"`
+}
+
+type GetUsersRequest struct {
+	Limit  int `query:"limit" validate:"min=1,max=100"`
+	Offset int `query:"offset" validate:"min=0"`
+}
+
+type GetUserRequest struct {
+	Username string `params:"username" validate:"required,username"`
+}
+
+type EditUserRequest struct {
+	Username   string         `params:"username" validate:"required,username"`
+	UpdateData UpdateUserData `json:"updateData" validate:"required"`
+}
+
+type UpdateUserData struct {
+	FirstName *string `json:"first_name" validate:"omitempty,max=50"`
+	LastName  *string `json:"last_name" validate:"omitempty,max=50"`
+	// Пустая строка удаляет адрес
+	Email              *string `json:"email" validate:"omitempty,max=254,eq=|email"`
+	Language           *string `json:"language" validate:"omitempty,oneof=ru en"`
+	EmailNotifications *bool   `json:"email_notifications"`
+	Password           *string `json:"password" validate:"omitempty,min=8,max=72"`
+}
+
+type GetCurrentUserOrganizationsRequest struct {
+	Limit    int    `query:"limit" validate:"min=1,max=100"`
+	Offset   int    `query:"offset" validate:"min=0"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetTenderVersionsRequest struct {
+	TenderID string `params:"tenderId" validate:"required"`
+	Limit    int    `query:"limit" validate:"min=1,max=100"`
+	Offset   int    `query:"offset" validate:"min=0"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetTenderVersionRequest struct {
+	TenderID string `params:"tenderId" validate:"required"`
+	Version  int    `params:"version" validate:"min=1"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetTenderDiffRequest struct {
+	TenderID string `params:"tenderId" validate:"required"`
+	From     int    `query:"from" validate:"min=1"`
+	To       int    `query:"to" validate:"min=1"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetBidVersionsRequest struct {
+	BidID    string `params:"bidId" validate:"required"`
+	Limit    int    `query:"limit" validate:"min=1,max=100"`
+	Offset   int    `query:"offset" validate:"min=0"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetBidVersionRequest struct {
+	BidID    string `params:"bidId" validate:"required"`
+	Version  int    `params:"version" validate:"min=1"`
+	Username string `query:"username" validate:"required"`
+}
+
+type GetBidDiffRequest struct {
+	BidID    string `params:"bidId" validate:"required"`
+	From     int    `query:"from" validate:"min=1"`
+	To       int    `query:"to" validate:"min=1"`
+	Username string `query:"username" validate:"required"`
+}
+
+type SearchBidsRequest struct {
+	Query    string `query:"q" validate:"required,max=200"`
+	TenderID string `query:"tenderId"`
+	Limit    int    `query:"limit" validate:"min=1,max=100"`
+	Offset   int    `query:"offset" validate:"min=0"`
+	Username string `query:"username" validate:"required"`
+}
+
+type StreamTenderEventsRequest struct {
+	TenderID    string `params:"tenderId" validate:"required"`
+	LastEventID int64  `query:"lastEventId" validate:"min=0"`
+	Username    string `query:"username" validate:"required"`
+}
+
+type GetNotificationsRequest struct {
+	Unread   bool   `query:"unread"`
+	Limit    int    `query:"limit" validate:"min=1,max=100"`
+	Offset   int    `query:"offset" validate:"min=0"`
+	Username string `query:"username" validate:"required"`
+}
+
+type NotificationRequest struct {
+	NotificationID string `params:"notificationId" validate:"required"`
+	Username       string `query:"username" validate:"required"`
+}
+
+type GetUnreadNotificationsRequest struct {
+	Username string `query:"username" validate:"required"`
+}
+
+type MarkAllNotificationsReadRequest struct {
+	Username string `query:"username" validate:"required"`
+}
@@ -0,0 +1,14 @@
+package model
+
+// Найденный тендер с релевантностью и фрагментом текста, где совпадения выделены <b></b>
+type TenderSearchResult struct {
+	Tender
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type BidSearchResult struct {
+	Bid
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
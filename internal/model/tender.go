@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"time"
 )
 
@@ -12,6 +13,25 @@ const (
 	TenderStatusClosed    TenderStatus = "Closed"
 )
 
+// Допустимые переходы статусов тендера: из статуса -> в статусы
+type TenderStatusTransitions map[TenderStatus][]TenderStatus
+
+// reopenTo задаёт статусы, в которые можно вернуть закрытый тендер
+func NewTenderStatusTransitions(reopenTo ...TenderStatus) TenderStatusTransitions {
+	return TenderStatusTransitions{
+		TenderStatusCreated:   {TenderStatusPublished, TenderStatusClosed},
+		TenderStatusPublished: {TenderStatusClosed},
+		TenderStatusClosed:    slices.Clone(reopenTo),
+	}
+}
+
+func (t TenderStatusTransitions) Check(from TenderStatus, to TenderStatus) error {
+	if slices.Contains(t[from], to) {
+		return nil
+	}
+	return &TransitionError{From: string(from), To: string(to)}
+}
+
 type TenderServiceType string
 
 const (
@@ -28,7 +48,19 @@ type Tender struct {
 	Status          TenderStatus      `json:"status" `
 	OrganizationID  string            `json:"organizationId" `
 	CreatorUsername string            `json:"creatorUsername" `
-	Version         int               `json:"version"`
-	CreatedAt       time.Time         `json:"created_at"`
-	UpdatedAt       time.Time         `json:"updated_at"`
+	BudgetMin       *Decimal          `json:"budgetMin,omitempty"`
+	BudgetMax       *Decimal          `json:"budgetMax,omitempty"`
+	BudgetCurrency  *string           `json:"budgetCurrency,omitempty"`
+	Deadline        *time.Time        `json:"deadline,omitempty"`
+	// Редукцион: поставщики снижают цену до окончания окна торгов
+	Auction       bool       `json:"auction"`
+	AuctionEndsAt *time.Time `json:"auctionEndsAt,omitempty"`
+	Version       int        `json:"version"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// После дедлайна предложения не принимаются, даже если тендер ещё не закрыт
+func (t *Tender) IsExpired(now time.Time) bool {
+	return t.Deadline != nil && !now.Before(*t.Deadline)
 }
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenderStatusTransitionsCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		reopenTo []TenderStatus
+		from     TenderStatus
+		to       TenderStatus
+		allowed  bool
+	}{
+		{"created to published", nil, TenderStatusCreated, TenderStatusPublished, true},
+		{"created to closed", nil, TenderStatusCreated, TenderStatusClosed, true},
+		{"published to closed", nil, TenderStatusPublished, TenderStatusClosed, true},
+		{"published back to created", nil, TenderStatusPublished, TenderStatusCreated, false},
+		{"same status", nil, TenderStatusPublished, TenderStatusPublished, false},
+		{"closed without reopen", nil, TenderStatusClosed, TenderStatusPublished, false},
+		{"closed to created without reopen", nil, TenderStatusClosed, TenderStatusCreated, false},
+		{"reopen to published", []TenderStatus{TenderStatusPublished}, TenderStatusClosed, TenderStatusPublished, true},
+		{"reopen to published only", []TenderStatus{TenderStatusPublished}, TenderStatusClosed, TenderStatusCreated, false},
+		{"reopen to created", []TenderStatus{TenderStatusCreated}, TenderStatusClosed, TenderStatusCreated, true},
+		{"reopen to both", []TenderStatus{TenderStatusCreated, TenderStatusPublished}, TenderStatusClosed, TenderStatusPublished, true},
+		{"unknown status", nil, TenderStatus("Archived"), TenderStatusClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewTenderStatusTransitions(tt.reopenTo...).Check(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			assert.EqualError(t, err, "invalid status transition from "+string(tt.from)+" to "+string(tt.to))
+		})
+	}
+}
+
+func TestNewTenderStatusTransitionsCopiesReopenStatuses(t *testing.T) {
+	reopenTo := []TenderStatus{TenderStatusPublished}
+	transitions := NewTenderStatusTransitions(reopenTo...)
+
+	// Изменение исходного среза не меняет таблицу переходов
+	reopenTo[0] = TenderStatusCreated
+	assert.NoError(t, transitions.Check(TenderStatusClosed, TenderStatusPublished))
+	assert.Error(t, transitions.Check(TenderStatusClosed, TenderStatusCreated))
+}
+
+func TestTenderCheckAuction(t *testing.T) {
+	currency := "RUB"
+	endsAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before := endsAt.Add(-time.Hour)
+	after := endsAt.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		tender Tender
+		valid  bool
+	}{
+		{"not an auction", Tender{ServiceType: TenderServiceTypeConstruction}, true},
+		{"ends at without auction", Tender{AuctionEndsAt: &endsAt}, false},
+		{"auction without deadline", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, BudgetCurrency: &currency, AuctionEndsAt: &endsAt}, true},
+		{"auction ends before deadline", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, BudgetCurrency: &currency, AuctionEndsAt: &endsAt, Deadline: &after}, true},
+		{"auction ends at deadline", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, BudgetCurrency: &currency, AuctionEndsAt: &endsAt, Deadline: &endsAt}, false},
+		{"auction ends after deadline", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, BudgetCurrency: &currency, AuctionEndsAt: &endsAt, Deadline: &before}, false},
+		{"auction without currency", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, AuctionEndsAt: &endsAt}, false},
+		{"auction without end", Tender{Auction: true, ServiceType: TenderServiceTypeDelivery, BudgetCurrency: &currency}, false},
+		{"auction not for delivery", Tender{Auction: true, ServiceType: TenderServiceTypeManufacture, BudgetCurrency: &currency, AuctionEndsAt: &endsAt}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tender.CheckAuction()
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidAuction)
+		})
+	}
+}
+
+func TestTenderCheckBidPrice(t *testing.T) {
+	rub, usd := "RUB", "USD"
+	min, max := Decimal("100.00"), Decimal("200.00")
+	price := func(value Decimal) *Decimal { return &value }
+
+	tests := []struct {
+		name     string
+		tender   Tender
+		price    *Decimal
+		currency *string
+		err      error
+	}{
+		{"no price", Tender{BudgetCurrency: &rub, BudgetMin: &min}, nil, nil, nil},
+		{"price without currency", Tender{}, price("150.00"), nil, ErrCurrencyMismatch},
+		{"no budget", Tender{}, price("150.00"), &usd, nil},
+		{"within budget", Tender{BudgetCurrency: &rub, BudgetMin: &min, BudgetMax: &max}, price("150.00"), &rub, nil},
+		{"below budget", Tender{BudgetCurrency: &rub, BudgetMin: &min}, price("99.99"), &rub, ErrBidOutOfBudget},
+		{"above budget", Tender{BudgetCurrency: &rub, BudgetMax: &max}, price("200.01"), &rub, ErrBidOutOfBudget},
+		{"other currency", Tender{BudgetCurrency: &rub, BudgetMax: &max}, price("150.00"), &usd, ErrCurrencyMismatch},
+		{"other currency without range", Tender{Auction: true, BudgetCurrency: &rub}, price("150.00"), &usd, ErrCurrencyMismatch},
+		{"currency without range", Tender{Auction: true, BudgetCurrency: &rub}, price("150.00"), &rub, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tender.CheckBidPrice(tt.price, tt.currency)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -7,7 +7,38 @@ type User struct {
 	Username   string   `json:"username"`
 	First_name string   `json:"first_name"`
 	Last_name  string   `json:"last_name"`
+	// Адрес для писем-уведомлений, без него письма не отправляются; в ответах виден только в UserProfile
+	Email *string `json:"-"`
+	// Язык писем
+	Language Language `json:"-"`
+	// Отказ от писем-уведомлений, уведомления в приложении остаются
+	EmailNotifications bool `json:"-"`
+	// bcrypt-хеш пароля, записывается при создании и смене пароля; при чтении сотрудника не загружается
+	PasswordHash string `json:"-"`
 	Created_at time.Time `json:"created_at"`
 	Updated_at time.Time   `json:"updated_at"`
 }
 
+// UserProfile - запись сотрудника для него самого: с адресом и настройками писем
+type UserProfile struct {
+	User
+	Email              *string  `json:"email,omitempty"`
+	Language           Language `json:"language"`
+	EmailNotifications bool     `json:"email_notifications"`
+}
+
+func NewUserProfile(user *User) *UserProfile {
+	return &UserProfile{
+		User:               *user,
+		Email:              user.Email,
+		Language:           user.Language,
+		EmailNotifications: user.EmailNotifications,
+	}
+}
+
+type Language string
+
+const (
+	LanguageRu Language = "ru"
+	LanguageEn Language = "en"
+)
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserJSON(t *testing.T) {
+	email := "ivanov@example.com"
+	user := &User{Id: "user-1", Username: "ivanov", Email: &email, Language: LanguageEn, EmailNotifications: true, PasswordHash: "hash"}
+
+	t.Run("public user hides email and preferences", func(t *testing.T) {
+		data, err := json.Marshal(user)
+		require.NoError(t, err)
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(data, &fields))
+		assert.Equal(t, "ivanov", fields["username"])
+		assert.NotContains(t, fields, "email")
+		assert.NotContains(t, fields, "language")
+		assert.NotContains(t, fields, "email_notifications")
+		assert.NotContains(t, fields, "PasswordHash")
+	})
+
+	t.Run("profile shows email and preferences", func(t *testing.T) {
+		data, err := json.Marshal(NewUserProfile(user))
+		require.NoError(t, err)
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(data, &fields))
+		assert.Equal(t, "ivanov", fields["username"])
+		assert.Equal(t, email, fields["email"])
+		assert.Equal(t, "en", fields["language"])
+		assert.Equal(t, true, fields["email_notifications"])
+		assert.NotContains(t, fields, "PasswordHash")
+	})
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type VersionDiff struct {
+	FromVersion int           `json:"fromVersion"`
+	ToVersion   int           `json:"toVersion"`
+	Changes     []FieldChange `json:"changes"`
+}
+
+func DiffTenders(from *Tender, to *Tender) *VersionDiff {
+	diff := &VersionDiff{FromVersion: from.Version, ToVersion: to.Version, Changes: []FieldChange{}}
+	diff.add("name", from.Name, to.Name)
+	diff.add("description", from.Description, to.Description)
+	diff.add("serviceType", string(from.ServiceType), string(to.ServiceType))
+	diff.add("status", string(from.Status), string(to.Status))
+	diff.add("budgetMin", optional(from.BudgetMin), optional(to.BudgetMin))
+	diff.add("budgetMax", optional(from.BudgetMax), optional(to.BudgetMax))
+	diff.add("budgetCurrency", optional(from.BudgetCurrency), optional(to.BudgetCurrency))
+	diff.add("deadline", optionalTime(from.Deadline), optionalTime(to.Deadline))
+	return diff
+}
+
+func DiffBids(from *Bid, to *Bid) *VersionDiff {
+	diff := &VersionDiff{FromVersion: from.Version, ToVersion: to.Version, Changes: []FieldChange{}}
+	diff.add("name", from.Name, to.Name)
+	diff.add("description", from.Description, to.Description)
+	diff.add("status", string(from.Status), string(to.Status))
+	diff.add("price", optional(from.Price), optional(to.Price))
+	diff.add("currency", optional(from.Currency), optional(to.Currency))
+	return diff
+}
+
+func (d *VersionDiff) add(field string, from string, to string) {
+	if from != to {
+		d.Changes = append(d.Changes, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func optional[T ~string](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return string(*value)
+}
+
+func optionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
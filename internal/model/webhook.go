@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Подписка организации на события своих тендеров и предложений по ним
+type Webhook struct {
+	ID             string      `json:"id"`
+	OrganizationID string      `json:"organizationId"`
+	URL            string      `json:"url"`
+	Secret         string      `json:"-"`
+	EventTypes     []EventType `json:"eventTypes"`
+	CreatedBy      string      `json:"createdBy"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "Pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "Delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "Failed"
+)
+
+// Доставка события в webhook. Payload хранится, чтобы повторная отправка была побайтно той же
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhookId"`
+	EventID        int64                 `json:"eventId"`
+	EventType      EventType             `json:"eventType"`
+	Payload        json.RawMessage       `json:"-"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	LastError      *string               `json:"lastError,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+}
+
+// Доставка, взятая в работу, вместе с адресом и секретом подписи
+type WebhookDispatch struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
@@ -0,0 +1,40 @@
+package file
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/notifier"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fileNotifier складывает письма в каталог файлами .eml - для локальной разработки
+type fileNotifier struct {
+	dir  string
+	from string
+}
+
+func NewFileNotifier(dir string, from string) (notifier.Notifier, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileNotifier{dir: dir, from: from}, nil
+}
+
+func (n *fileNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	now := time.Now()
+	data, err := notifier.FormatMessage(n.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	// Время в имени упорядочивает письма, uuid исключает совпадения
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), uuid.NewString())
+	if err := os.WriteFile(filepath.Join(n.dir, name), data, 0o640); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
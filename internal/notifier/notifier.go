@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"time"
+)
+
+// Письмо, уже собранное на языке получателя
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier отправляет письма-уведомления сотрудникам
+type Notifier interface {
+	Send(context.Context, Message) error
+}
+
+type nopNotifier struct{}
+
+// NewNopNotifier используется, когда отправка писем не настроена
+func NewNopNotifier() Notifier {
+	return nopNotifier{}
+}
+
+func (nopNotifier) Send(context.Context, Message) error {
+	return nil
+}
+
+// FormatMessage собирает письмо в формате RFC 5322. Тема кодируется по RFC 2047,
+// поэтому переводы строк из названия предложения не попадут в заголовки
+func FormatMessage(from string, msg Message, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderBidEmail(t *testing.T) {
+	data := BidEmailData{FirstName: "Иван", BidName: "Поставка труб", TenderID: "tender-1", Feedback: "Снизьте цену"}
+
+	t.Run("russian", func(t *testing.T) {
+		msg, err := RenderBidEmail(model.LanguageRu, model.NotificationBidApproved, "ivanov@example.com", data)
+		require.NoError(t, err)
+		assert.Equal(t, "ivanov@example.com", msg.To)
+		assert.Equal(t, "Предложение «Поставка труб» одобрено", msg.Subject)
+		assert.True(t, strings.HasPrefix(msg.Body, "Здравствуйте, Иван!"))
+	})
+
+	t.Run("english feedback", func(t *testing.T) {
+		msg, err := RenderBidEmail(model.LanguageEn, model.NotificationBidFeedback, "ivanov@example.com", data)
+		require.NoError(t, err)
+		assert.Equal(t, `New feedback on bid "Поставка труб"`, msg.Subject)
+		assert.Contains(t, msg.Body, "Снизьте цену")
+	})
+
+	t.Run("unknown language falls back to russian", func(t *testing.T) {
+		msg, err := RenderBidEmail("de", model.NotificationBidRejected, "ivanov@example.com", BidEmailData{BidName: "Трубы"})
+		require.NoError(t, err)
+		assert.Equal(t, "Предложение «Трубы» отклонено", msg.Subject)
+		assert.True(t, strings.HasPrefix(msg.Body, "Здравствуйте!"))
+	})
+
+	t.Run("no template", func(t *testing.T) {
+		_, err := RenderBidEmail(model.LanguageRu, model.NotificationTenderClosed, "ivanov@example.com", data)
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatMessage(t *testing.T) {
+	msg := Message{To: "ivanov@example.com", Subject: "Предложение\r\nBcc: evil@example.com", Body: "Текст письма"}
+
+	data, err := FormatMessage("tender-api@example.com", msg, time.Now())
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	assert.Equal(t, "ivanov@example.com", parsed.Header.Get("To"))
+	assert.Empty(t, parsed.Header.Get("Bcc"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, msg.Subject, subject)
+}
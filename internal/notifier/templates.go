@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Данные для шаблонов писем о предложении
+type BidEmailData struct {
+	FirstName string
+	BidName   string
+	TenderID  string
+	Feedback  string
+}
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newEmailTemplate(subject string, body string) emailTemplate {
+	return emailTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+const (
+	footerRu = "\n\nПисьмо отправлено автоматически. Отключить письма можно в профиле сотрудника (email_notifications)."
+	footerEn = "\n\nThis email was sent automatically. You can turn emails off in your employee profile (email_notifications)."
+)
+
+// Письма отправляются только о решениях и отзывах; остальные уведомления остаются в приложении
+var emailTemplates = map[model.Language]map[model.NotificationType]emailTemplate{
+	model.LanguageRu: {
+		model.NotificationBidApproved: newEmailTemplate(
+			"Предложение «{{.BidName}}» одобрено",
+			"Здравствуйте{{with .FirstName}}, {{.}}{{end}}!\n\nВаше предложение «{{.BidName}}» по тендеру {{.TenderID}} одобрено."+footerRu,
+		),
+		model.NotificationBidRejected: newEmailTemplate(
+			"Предложение «{{.BidName}}» отклонено",
+			"Здравствуйте{{with .FirstName}}, {{.}}{{end}}!\n\nВаше предложение «{{.BidName}}» по тендеру {{.TenderID}} отклонено."+footerRu,
+		),
+		model.NotificationBidFeedback: newEmailTemplate(
+			"Новый отзыв на предложение «{{.BidName}}»",
+			"Здравствуйте{{with .FirstName}}, {{.}}{{end}}!\n\nНа ваше предложение «{{.BidName}}» по тендеру {{.TenderID}} оставлен отзыв:\n\n{{.Feedback}}"+footerRu,
+		),
+	},
+	model.LanguageEn: {
+		model.NotificationBidApproved: newEmailTemplate(
+			"Bid \"{{.BidName}}\" approved",
+			"Hello{{with .FirstName}} {{.}}{{end}},\n\nYour bid \"{{.BidName}}\" for tender {{.TenderID}} was approved."+footerEn,
+		),
+		model.NotificationBidRejected: newEmailTemplate(
+			"Bid \"{{.BidName}}\" rejected",
+			"Hello{{with .FirstName}} {{.}}{{end}},\n\nYour bid \"{{.BidName}}\" for tender {{.TenderID}} was rejected."+footerEn,
+		),
+		model.NotificationBidFeedback: newEmailTemplate(
+			"New feedback on bid \"{{.BidName}}\"",
+			"Hello{{with .FirstName}} {{.}}{{end}},\n\nYou have new feedback on your bid \"{{.BidName}}\" for tender {{.TenderID}}:\n\n{{.Feedback}}"+footerEn,
+		),
+	},
+}
+
+// RenderBidEmail собирает письмо на языке получателя; неизвестный язык заменяется русским
+func RenderBidEmail(language model.Language, notificationType model.NotificationType, to string, data BidEmailData) (Message, error) {
+	templates, ok := emailTemplates[language]
+	if !ok {
+		templates = emailTemplates[model.LanguageRu]
+	}
+	tmpl, ok := templates[notificationType]
+	if !ok {
+		return Message{}, fmt.Errorf("no email template for notification type %s", notificationType)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render email body: %w", err)
+	}
+
+	return Message{To: to, Subject: subject.String(), Body: body.String()}, nil
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	secret := []byte("secret")
+
+	t.Run("round trip", func(t *testing.T) {
+		token, expiresAt, err := GenerateToken("ivanov", secret, time.Hour)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+
+		claims, err := ParseToken(token, secret)
+		require.NoError(t, err)
+		assert.Equal(t, "ivanov", claims.Username)
+		assert.Equal(t, "ivanov", claims.Subject)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		token, _, err := GenerateToken("ivanov", secret, time.Hour)
+		require.NoError(t, err)
+
+		_, err = ParseToken(token, []byte("other"))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token, _, err := GenerateToken("ivanov", secret, -time.Minute)
+		require.NoError(t, err)
+
+		_, err = ParseToken(token, secret)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestParseToken(t *testing.T) {
+	secret := []byte("secret")
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := ParseToken("not-a-token", secret)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unexpected signing method", func(t *testing.T) {
+		claims := Claims{Username: "ivanov", RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(secret)
+		require.NoError(t, err)
+
+		_, err = ParseToken(token, secret)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		claims := Claims{Username: "ivanov", RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = ParseToken(token, secret)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("empty username", func(t *testing.T) {
+		claims := Claims{RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		require.NoError(t, err)
+
+		_, err = ParseToken(token, secret)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("password")
+	require.NoError(t, err)
+
+	assert.NoError(t, CheckPassword(hash, "password"))
+	assert.ErrorIs(t, CheckPassword(hash, "wrong"), ErrPasswordMismatch)
+}
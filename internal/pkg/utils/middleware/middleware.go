@@ -1,32 +1,79 @@
 package middleware
 
 import (
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"io"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-// Middleware для имитации авторизации по Bearer токену 
-func AuthMiddleware(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"reason": "Заголовок Authorization отсутствует",
-		})
-	}
+const UsernameKey = "username"
+
+// Middleware авторизации по подписанному JWT в заголовке Bearer
+func AuthMiddleware(secret []byte) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"reason": "Заголовок Authorization отсутствует",
+			})
+		}
+
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"reason": "Неверный формат заголовка Authorization. Ожидается 'Bearer <токен>'",
+			})
+		}
+
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+		if token == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"reason": "Токен авторизации не предоставлен после 'Bearer '",
+			})
+		}
 
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"reason": "Неверный формат заголовка Authorization. Ожидается 'Bearer <токен>'",
-		})
+		claims, err := utils.ParseToken(token, secret)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"reason": "Недействительный токен авторизации",
+			})
+		}
+
+		c.Locals("token", token)
+		c.Locals(UsernameKey, claims.Username)
+		return c.Next()
 	}
+}
+
+// BodyLimit ограничивает тело запроса маршрута. Сервер работает со StreamRequestBody,
+// поэтому тело больше стандартного лимита не буферизуется до этой проверки
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := c.Request()
+		if req.Header.ContentLength() > limit {
+			c.Context().SetConnectionClose()
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"reason": "Тело запроса превышает допустимый размер",
+			})
+		}
 
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-	if token == "" { 
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"reason": "Токен авторизации не предоставлен после 'Bearer '",
-		})
+		// Размер chunked-тела заранее неизвестен - дочитываем его не дальше лимита
+		if req.Header.ContentLength() < 0 && req.IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"reason": "Не удалось прочитать тело запроса",
+				})
+			}
+			if len(body) > limit {
+				c.Context().SetConnectionClose()
+				return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+					"reason": "Тело запроса превышает допустимый размер",
+				})
+			}
+			req.SetBody(body)
+		}
+		return c.Next()
 	}
-	c.Locals("token", token)
-	return c.Next()
 }
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	secret := []byte("secret")
+
+	app := fiber.New()
+	app.Use(AuthMiddleware(secret))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals(UsernameKey).(string))
+	})
+
+	request := func(authorization string) (int, string) {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set(fiber.HeaderAuthorization, authorization)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		token, _, err := utils.GenerateToken("ivanov", secret, time.Hour)
+		require.NoError(t, err)
+
+		status, body := request("Bearer " + token)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "ivanov", body)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		status, _ := request("")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("not bearer", func(t *testing.T) {
+		status, _ := request("Basic aXZhbm92OnBhc3N3b3Jk")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		status, _ := request("Bearer ")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("foreign secret", func(t *testing.T) {
+		token, _, err := utils.GenerateToken("ivanov", []byte("other"), time.Hour)
+		require.NoError(t, err)
+
+		status, _ := request("Bearer " + token)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+}
+
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true, BodyLimit: 16})
+	app.Post("/upload", BodyLimit(64), func(c *fiber.Ctx) error {
+		return c.SendString(strconv.Itoa(len(c.Body())))
+	})
+	app.Use(BodyLimit(16))
+	app.Post("/", func(c *fiber.Ctx) error {
+		return c.SendString(strconv.Itoa(len(c.Body())))
+	})
+
+	request := func(path string, body io.Reader) (int, string) {
+		req := httptest.NewRequest(fiber.MethodPost, path, body)
+		if req.ContentLength < 0 {
+			req.TransferEncoding = []string{"chunked"}
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(respBody)
+	}
+	// Reader без известной длины уходит chunked-телом
+	chunked := func(size int) io.Reader {
+		return io.MultiReader(strings.NewReader(strings.Repeat("a", size)))
+	}
+
+	t.Run("within default limit", func(t *testing.T) {
+		status, body := request("/", strings.NewReader(strings.Repeat("a", 16)))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "16", body)
+	})
+
+	t.Run("over default limit", func(t *testing.T) {
+		status, _ := request("/", strings.NewReader(strings.Repeat("a", 32)))
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	})
+
+	t.Run("route limit", func(t *testing.T) {
+		status, body := request("/upload", strings.NewReader(strings.Repeat("a", 32)))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "32", body)
+
+		status, _ = request("/upload", strings.NewReader(strings.Repeat("a", 65)))
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	})
+
+	t.Run("chunked body", func(t *testing.T) {
+		status, body := request("/", chunked(16))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "16", body)
+
+		status, _ = request("/", chunked(17))
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	})
+}
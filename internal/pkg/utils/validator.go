@@ -3,12 +3,18 @@ package utils
 import (
 	"Backend-trainee-assignment-autumn-2024/internal/model"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
 var ValidatorInstance = validator.New()
 
+// Уникальный slug пользователя, ограничен размером колонки employee.username
+var usernameRegexp = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
 func init() {
 	ValidatorInstance.RegisterValidation("bidstatus", func(fl validator.FieldLevel) bool {
 		status := fl.Field().String()
@@ -39,6 +45,50 @@ func init() {
 			return false
 		}
 	})
+
+	ValidatorInstance.RegisterValidation("tenderstatus", func(fl validator.FieldLevel) bool {
+		status := model.TenderStatus(fl.Field().String())
+		switch status {
+		case model.TenderStatusCreated, model.TenderStatusPublished, model.TenderStatusClosed:
+			return true
+		default:
+			return false
+		}
+	})
+
+	ValidatorInstance.RegisterValidation("organizationtype", func(fl validator.FieldLevel) bool {
+		organizationType := model.OrganizationType(fl.Field().String())
+		switch organizationType {
+		case model.OrganizationTypeIE, model.OrganizationTypeLLC, model.OrganizationTypeJSC:
+			return true
+		default:
+			return false
+		}
+	})
+
+	ValidatorInstance.RegisterValidation("eventtype", func(fl validator.FieldLevel) bool {
+		switch model.EventType(fl.Field().String()) {
+		case model.EventTenderCreated, model.EventTenderUpdated, model.EventTenderPublished, model.EventTenderClosed,
+			model.EventBidCreated, model.EventBidUpdated, model.EventBidSubmitted, model.EventBidCanceled, model.EventBidApproved, model.EventBidRejected:
+			return true
+		default:
+			return false
+		}
+	})
+
+	ValidatorInstance.RegisterValidation("amount", func(fl validator.FieldLevel) bool {
+		return model.Decimal(fl.Field().String()).IsValid()
+	})
+
+	ValidatorInstance.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return usernameRegexp.MatchString(fl.Field().String())
+	})
+
+	// Адрес webhook принимается только с https, доставка по http раскрыла бы тело события
+	ValidatorInstance.RegisterValidation("https_url", func(fl validator.FieldLevel) bool {
+		u, err := url.Parse(fl.Field().String())
+		return err == nil && strings.EqualFold(u.Scheme, "https") && u.Hostname() != ""
+	})
 }
 
 func ValidateStruct(s interface{}) error {
@@ -0,0 +1,140 @@
+package publisher
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Publisher доставляет события из outbox подписчикам, ошибка оставляет событие в очереди
+type Publisher interface {
+	Publish(context.Context, model.Event) error
+}
+
+type logPublisher struct {
+	logger *slog.Logger
+}
+
+// NewLogPublisher только пишет события в лог - для запуска без внешнего брокера
+func NewLogPublisher(logger *slog.Logger) Publisher {
+	return &logPublisher{logger}
+}
+
+func (p *logPublisher) Publish(ctx context.Context, event model.Event) error {
+	p.logger.InfoContext(ctx, "Domain event published",
+		slog.Int64("id", event.ID),
+		slog.String("type", string(event.Type)),
+		slog.String("aggregateType", string(event.AggregateType)),
+		slog.String("aggregateID", event.AggregateID),
+		slog.String("tenderID", event.TenderID),
+	)
+	return nil
+}
+
+// MemoryPublisher хранит опубликованные события в памяти процесса, используется в тестах
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []model.Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event model.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *MemoryPublisher) Events() []model.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	events := make([]model.Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+type multiPublisher []Publisher
+
+// NewMultiPublisher публикует событие во все publisher по очереди, ошибка любого повторит событие целиком,
+// поэтому publisher до первого ненадёжного должны переносить повтор
+func NewMultiPublisher(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, event model.Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Broadcaster будит подписчиков тендера, когда relay зафиксировал публикацию его события. Сами события
+// подписчики читают из outbox, поэтому пропущенный сигнал ничего не теряет
+type Broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+	closed      bool
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{subscribers: make(map[string]map[chan struct{}]struct{})}
+}
+
+func (b *Broadcaster) Publish(ctx context.Context, event model.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers[event.TenderID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe возвращает канал сигналов и функцию отписки; после Close канал закрыт
+func (b *Broadcaster) Subscribe(tenderID string) (<-chan struct{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subscribers[tenderID] == nil {
+		b.subscribers[tenderID] = make(map[chan struct{}]struct{})
+	}
+	b.subscribers[tenderID][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[tenderID][ch]; ok {
+			delete(b.subscribers[tenderID], ch)
+			if len(b.subscribers[tenderID]) == 0 {
+				delete(b.subscribers, tenderID)
+			}
+			close(ch)
+		}
+	}
+}
+
+// Close закрывает каналы всех подписчиков, чтобы открытые потоки завершились до остановки сервера
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for tenderID, channels := range b.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(b.subscribers, tenderID)
+	}
+}
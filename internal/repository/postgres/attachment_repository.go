@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type attachmentRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAttachmentRepository(db *sql.DB, logger *slog.Logger) repository.AttachmentRepository {
+	return &attachmentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) (*model.Attachment, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO attachment (id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for creating attachment: %w", err)
+	}
+	defer stmt.Close()
+
+	created := &model.Attachment{}
+	err = stmt.QueryRowContext(ctx,
+		attachment.ID,
+		attachment.OwnerType,
+		attachment.OwnerID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		attachment.CreatedBy,
+		attachment.CreatedAt,
+	).Scan(
+		&created.ID,
+		&created.OwnerType,
+		&created.OwnerID,
+		&created.FileName,
+		&created.ContentType,
+		&created.Size,
+		&created.Checksum,
+		&created.StorageKey,
+		&created.CreatedBy,
+		&created.CreatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating attachment", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *attachmentRepository) GetAttachmentById(ctx context.Context, id string) (*model.Attachment, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+		FROM attachment
+		WHERE id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting attachment: %w", err)
+	}
+	defer stmt.Close()
+
+	attachment := &model.Attachment{}
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&attachment.ID,
+		&attachment.OwnerType,
+		&attachment.OwnerID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.StorageKey,
+		&attachment.CreatedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAttachmentNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error getting attachment", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *attachmentRepository) GetAttachments(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string) ([]model.Attachment, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+		FROM attachment
+		WHERE owner_type = $1 AND owner_id = $2
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting attachments: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, ownerType, ownerID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting attachments", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []model.Attachment
+	for rows.Next() {
+		attachment := model.Attachment{}
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.OwnerType,
+			&attachment.OwnerID,
+			&attachment.FileName,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.StorageKey,
+			&attachment.CreatedBy,
+			&attachment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		DELETE FROM attachment
+		WHERE id = $1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for deleting attachment: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting attachment", slog.Any("error", err))
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrAttachmentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestAttachment(t *testing.T) (*sql.DB, sqlmock.Sqlmock, repository.AttachmentRepository) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	logger := slog.Default()
+	repo := NewAttachmentRepository(db, logger)
+	return db, mock, repo
+}
+
+var attachmentColumns = []string{"id", "owner_type", "owner_id", "file_name", "content_type", "size", "checksum", "storage_key", "created_by", "created_at"}
+
+const testChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestCreateAttachment(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO attachment (id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+	`)
+	now := time.Now()
+	attachment := &model.Attachment{
+		ID:          "attachment-1",
+		OwnerType:   model.AttachmentOwnerTender,
+		OwnerID:     "tender-1",
+		FileName:    "spec.pdf",
+		ContentType: "application/pdf",
+		Size:        1024,
+		Checksum:    testChecksum,
+		StorageKey:  "tender/tender-1/attachment-1",
+		CreatedBy:   "ivanov",
+		CreatedAt:   now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().
+			WithArgs("attachment-1", model.AttachmentOwnerTender, "tender-1", "spec.pdf", "application/pdf", int64(1024), testChecksum, "tender/tender-1/attachment-1", "ivanov", now).
+			WillReturnRows(sqlmock.NewRows(attachmentColumns).
+				AddRow("attachment-1", "Tender", "tender-1", "spec.pdf", "application/pdf", 1024, testChecksum, "tender/tender-1/attachment-1", "ivanov", now))
+
+		created, err := repo.CreateAttachment(context.Background(), attachment)
+		assert.NoError(t, err)
+		assert.Equal(t, "attachment-1", created.ID)
+		assert.Equal(t, model.AttachmentOwnerTender, created.OwnerType)
+		assert.Equal(t, int64(1024), created.Size)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrConnDone)
+
+		created, err := repo.CreateAttachment(context.Background(), attachment)
+		assert.Error(t, err)
+		assert.Nil(t, created)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAttachmentById(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+		FROM attachment
+		WHERE id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("attachment-1").WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow("attachment-1", "Bid", "bid-1", "quote.pdf", "application/pdf", 2048, testChecksum, "bid/bid-1/attachment-1", "petrov", time.Now()))
+
+		attachment, err := repo.GetAttachmentById(context.Background(), "attachment-1")
+		assert.NoError(t, err)
+		assert.Equal(t, model.AttachmentOwnerBid, attachment.OwnerType)
+		assert.Equal(t, "bid/bid-1/attachment-1", attachment.StorageKey)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("attachment-1").WillReturnError(sql.ErrNoRows)
+
+		attachment, err := repo.GetAttachmentById(context.Background(), "attachment-1")
+		assert.ErrorIs(t, err, model.ErrAttachmentNotFound)
+		assert.Nil(t, attachment)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAttachments(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, owner_type, owner_id, file_name, content_type, size, checksum, storage_key, created_by, created_at
+		FROM attachment
+		WHERE owner_type = $1 AND owner_id = $2
+		ORDER BY created_at, id
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(model.AttachmentOwnerTender, "tender-1").WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow("attachment-1", "Tender", "tender-1", "spec.pdf", "application/pdf", 1024, testChecksum, "tender/tender-1/attachment-1", "ivanov", time.Now()).
+			AddRow("attachment-2", "Tender", "tender-1", "drawing.png", "image/png", 4096, testChecksum, "tender/tender-1/attachment-2", "ivanov", time.Now()))
+
+		attachments, err := repo.GetAttachments(context.Background(), model.AttachmentOwnerTender, "tender-1")
+		assert.NoError(t, err)
+		assert.Len(t, attachments, 2)
+		assert.Equal(t, "drawing.png", attachments[1].FileName)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrConnDone)
+
+		attachments, err := repo.GetAttachments(context.Background(), model.AttachmentOwnerTender, "tender-1")
+		assert.Error(t, err)
+		assert.Nil(t, attachments)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		DELETE FROM attachment
+		WHERE id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("attachment-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.DeleteAttachment(context.Background(), "attachment-1")
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestAttachment(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("attachment-1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteAttachment(context.Background(), "attachment-1")
+		assert.ErrorIs(t, err, model.ErrAttachmentNotFound)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
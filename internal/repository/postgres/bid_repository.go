@@ -5,6 +5,7 @@ import (
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -38,9 +39,9 @@ func (r *bidRepository) CreateBid(ctx context.Context, bidRequest *model.Bid) (*
 	}()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement: %w", err)
@@ -48,11 +49,19 @@ func (r *bidRepository) CreateBid(ctx context.Context, bidRequest *model.Bid) (*
 	defer stmt.Close()
 
 	var bid model.Bid
-	err = stmt.QueryRowContext(ctx, bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt).Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
+	err = stmt.QueryRowContext(ctx, bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, bidRequest.Price, bidRequest.Currency, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt).Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Price, &bid.Currency, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
+	event, err := model.NewBidEvent("", &bid)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -62,7 +71,7 @@ func (r *bidRepository) CreateBid(ctx context.Context, bidRequest *model.Bid) (*
 
 func (r *bidRepository) GetBidById(ctx context.Context, id string) (*model.Bid, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-	SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+	SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 	FROM bid
 	WHERE id = $1`)
 	if err != nil {
@@ -80,6 +89,8 @@ func (r *bidRepository) GetBidById(ctx context.Context, id string) (*model.Bid,
 		&bid.AuthorType,
 		&bid.AuthorID,
 		&bid.CreatorUsername,
+		&bid.Price,
+		&bid.Currency,
 		&bid.Version,
 		&bid.CreatedAt,
 		&bid.UpdatedAt,
@@ -89,16 +100,18 @@ func (r *bidRepository) GetBidById(ctx context.Context, id string) (*model.Bid,
 			r.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
 			return nil, fmt.Errorf("failed to execute query: %w", err)
 		}
-		return nil, err
+		return nil, model.ErrBidNotFound
 	}
 	return &bid, nil
 }
 
-func (r *bidRepository) GetBidByUsername(ctx context.Context, limit int, offset int, username string) ([]model.Bid, error) {
+func (r *bidRepository) GetBidByUsername(ctx context.Context, limit int, offset int, cursor *model.Cursor, username string) ([]model.Bid, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE creator_username = $1
+		AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+		ORDER BY name, id
 		LIMIT $2 OFFSET $3
 	`)
 	if err != nil {
@@ -106,7 +119,8 @@ func (r *bidRepository) GetBidByUsername(ctx context.Context, limit int, offset
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, username, limit, offset)
+	cursorName, cursorID := cursorArgs(cursor)
+	rows, err := stmt.QueryContext(ctx, username, limit, offset, cursorName, cursorID)
 	if err != nil {
 		if err != sql.ErrNoRows {
 			r.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
@@ -119,7 +133,7 @@ func (r *bidRepository) GetBidByUsername(ctx context.Context, limit int, offset
 	var bids []model.Bid
 	for rows.Next() {
 		bid := model.Bid{}
-		err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
+		err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Price, &bid.Currency, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -129,19 +143,70 @@ func (r *bidRepository) GetBidByUsername(ctx context.Context, limit int, offset
 	return bids, nil
 }
 
-func (r *bidRepository) GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string) ([]model.Bid, error) {
-	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
-		FROM bid
-		WHERE tender_id = $1
+func (r *bidRepository) GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, cursor *model.Cursor, sort model.BidSort, username string) ([]model.Bid, error) {
+
+	sortColumn, castType := "b.name", "varchar"
+	switch sort.Field {
+	case model.BidSortPrice:
+		sortColumn, castType = fmt.Sprintf("COALESCE(b.price, '%s'::numeric)", sort.MissingValue()), "numeric"
+	case model.BidSortScore:
+		sortColumn, castType = fmt.Sprintf("COALESCE(bs.score, '%s'::numeric)", sort.MissingValue()), "numeric"
+	}
+	order, comparison := "ASC", ">"
+	if sort.Desc {
+		order, comparison = "DESC", "<"
+	}
+
+	// Автор видит свои предложения в любом статусе, ответственные за тендер - только опубликованные и рассмотренные
+	// Оценка считается только для ответственных за тендер, автору она не раскрывается
+	stmt, err := r.db.PrepareContext(ctx, fmt.Sprintf(`
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, b.author_id, b.creator_username, b.price, b.currency, b.version, b.created_at, b.updated_at, bs.score
+		FROM bid b
+		JOIN tender t ON t.id = b.tender_id
+		LEFT JOIN LATERAL (
+			SELECT CASE WHEN COUNT(s.criterion_id) = 0 THEN NULL
+				ELSE ROUND(SUM(c.weight * COALESCE(s.score, 0)) / SUM(c.weight), 4) END AS score
+			FROM tender_criterion c
+			LEFT JOIN (
+				SELECT criterion_id, AVG(score) AS score
+				FROM bid_score
+				WHERE bid_id = b.id
+				GROUP BY criterion_id
+			) s ON s.criterion_id = c.id
+			WHERE c.tender_id = b.tender_id AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $4
+			)
+		) bs ON true
+		WHERE b.tender_id = $1
+		AND (
+			b.creator_username = $4
+			OR (b.author_type = 'Organization' AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = b.author_id AND e.username = $4
+			))
+			OR (b.status IN ('Published', 'Approved', 'Rejected') AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $4
+			))
+		)
+		AND ($5::text IS NULL OR (%[1]s, b.id) %[2]s ($5::%[3]s, $6))
+		ORDER BY %[1]s %[4]s, b.id %[4]s
 		LIMIT $2 OFFSET $3
-	`)
+	`, sortColumn, comparison, castType, order))
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, tenderID, limit, offset)
+	cursorValue, cursorID := cursorArgs(cursor)
+	rows, err := stmt.QueryContext(ctx, tenderID, limit, offset, username, cursorValue, cursorID)
 	if err != nil {
 		if err != sql.ErrNoRows {
 			r.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
@@ -154,7 +219,7 @@ func (r *bidRepository) GetTenderBids(ctx context.Context, tenderID string, limi
 	var bids []model.Bid
 	for rows.Next() {
 		bid := model.Bid{}
-		err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
+		err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Price, &bid.Currency, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt, &bid.Score)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -164,30 +229,6 @@ func (r *bidRepository) GetTenderBids(ctx context.Context, tenderID string, limi
 	return bids, nil
 }
 
-func (r *bidRepository) GetBidStatus(ctx context.Context, bidID string) (model.BidStatus, error) {
-	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT status
-		FROM bid
-		WHERE id = $1
-	`)
-	if err != nil {
-		return "", fmt.Errorf("failed to prepare statement: %w", err)
-	}
-	defer stmt.Close()
-
-	var status model.BidStatus
-	err = stmt.QueryRowContext(ctx, bidID).Scan(&status)
-	if err != nil {
-		if err != sql.ErrNoRows {
-			r.logger.ErrorContext(ctx, "Error getting bid status", slog.Any("error", err))
-			return "", fmt.Errorf("failed to execute query: %w", err)
-		}
-		return "", err
-	}
-
-	return status, nil
-}
-
 func (r *bidRepository) UpdateBid(ctx context.Context, bid *model.Bid) (*model.Bid, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -201,18 +242,62 @@ func (r *bidRepository) UpdateBid(ctx context.Context, bid *model.Bid) (*model.B
 		}
 	}()
 
+	updatedBid, err := r.updateBid(ctx, tx, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updatedBid, nil
+}
+
+// updateBid сохраняет текущую версию предложения в историю и записывает новую в рамках транзакции
+func (r *bidRepository) updateBid(ctx context.Context, tx *sql.Tx, bid *model.Bid) (*model.Bid, error) {
 	oldVersion := bid.Version
 	bid.Version++
 	bid.UpdatedAt = time.Now()
 
+	// Текущая версия сохраняется в историю до изменения
+	historyStmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO bid_history (
+            id, bid_id, name, description, status, tender_id, 
+            author_type, author_id, creator_username, price, currency, 
+            version, created_at, updated_at
+        )
+        SELECT $1, id, name, description, status, tender_id,
+            author_type, author_id, creator_username, price, currency,
+            version, created_at, updated_at
+        FROM bid
+        WHERE id = $2 AND version = $3
+        RETURNING status
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare history statement: %w", err)
+	}
+	defer historyStmt.Close()
+
+	// Предыдущий статус нужен, чтобы определить тип события
+	var previousStatus model.BidStatus
+	err = historyStmt.QueryRowContext(ctx, uuid.New().String(), bid.ID, oldVersion).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionConflict
+		}
+		return nil, fmt.Errorf("failed to insert bid history: %w", err)
+	}
+
 	stmt, err := tx.PrepareContext(ctx, `
         UPDATE bid 
         SET name = $1, description = $2, status = $3, tender_id = $4, 
             author_type = $5, author_id = $6, creator_username = $7, 
-            version = $8, created_at = $9, updated_at = $10 
-        WHERE id = $11
+            price = $8, currency = $9, 
+            version = $10, created_at = $11, updated_at = $12 
+        WHERE id = $13 AND version = $14
         RETURNING id, name, description, status, tender_id, author_type, 
-            author_id, creator_username, version, created_at, updated_at
+            author_id, creator_username, price, currency, version, created_at, updated_at
     `)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare update statement: %w", err)
@@ -228,10 +313,13 @@ func (r *bidRepository) UpdateBid(ctx context.Context, bid *model.Bid) (*model.B
 		bid.AuthorType,
 		bid.AuthorID,
 		bid.CreatorUsername,
+		bid.Price,
+		bid.Currency,
 		bid.Version,
 		bid.CreatedAt,
 		bid.UpdatedAt,
 		bid.ID,
+		oldVersion,
 	).Scan(
 		&updatedBid.ID,
 		&updatedBid.Name,
@@ -241,52 +329,33 @@ func (r *bidRepository) UpdateBid(ctx context.Context, bid *model.Bid) (*model.B
 		&updatedBid.AuthorType,
 		&updatedBid.AuthorID,
 		&updatedBid.CreatorUsername,
+		&updatedBid.Price,
+		&updatedBid.Currency,
 		&updatedBid.Version,
 		&updatedBid.CreatedAt,
 		&updatedBid.UpdatedAt,
 	)
 	if err != nil {
+		// Строка не найдена - версию уже изменил другой запрос
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update bid: %w", err)
 	}
 
-	historyStmt, err := tx.PrepareContext(ctx, `
-        INSERT INTO bid_history (
-            id, bid_id, name, description, status, tender_id, 
-            author_type, author_id, creator_username, 
-            version, created_at, updated_at
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-    `)
+	event, err := model.NewBidEvent(previousStatus, updatedBid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare history statement: %w", err)
-	}
-	defer historyStmt.Close()
-
-	_, err = historyStmt.ExecContext(ctx,
-		uuid.New().String(),
-		bid.ID,
-		bid.Name,
-		bid.Description,
-		bid.Status,
-		bid.TenderID,
-		bid.AuthorType,
-		bid.AuthorID,
-		bid.CreatorUsername,
-		oldVersion,
-		bid.CreatedAt,
-		time.Now(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert bid history: %w", err)
+		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
 	}
 
 	return updatedBid, nil
 }
 
-func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, version int) (*model.Bid, error) {
+// RollbackBidVersion восстанавливает поля версии; check проверяет восстановленное предложение до записи
+func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, version int, check func(restored *model.Bid) error) (*model.Bid, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -301,8 +370,8 @@ func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, ve
 
 	var historyBid model.Bid
 	err = tx.QueryRowContext(ctx, `
-		SELECT id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
+		SELECT bid_id, name, description, status, tender_id, author_type, 
+			author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid_history
 		WHERE bid_id = $1 AND version = $2
 	`, bidID, version).Scan(
@@ -314,22 +383,51 @@ func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, ve
 		&historyBid.AuthorType,
 		&historyBid.AuthorID,
 		&historyBid.CreatorUsername,
+		&historyBid.Price,
+		&historyBid.Currency,
 		&historyBid.Version,
 		&historyBid.CreatedAt,
 		&historyBid.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionNotFound
+		}
 		return nil, fmt.Errorf("failed to query bid history: %w", err)
 	}
+	if err := check(&historyBid); err != nil {
+		return nil, err
+	}
 
+	// Откат - это новая правка: текущее состояние уходит в историю
+	var currentVersion int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bid_history (
+			id, bid_id, name, description, status, tender_id, 
+			author_type, author_id, creator_username, price, currency, 
+			version, created_at, updated_at
+		)
+		SELECT $1, id, name, description, status, tender_id,
+			author_type, author_id, creator_username, price, currency,
+			version, created_at, updated_at
+		FROM bid
+		WHERE id = $2
+		RETURNING version
+	`, uuid.New().String(), bidID).Scan(&currentVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("failed to insert bid history: %w", err)
+	}
+
+	// Статус не откатывается, его меняют только переходы
 	stmt, err := tx.PrepareContext(ctx, `
 		UPDATE bid
-		SET name = $1, description = $2, status = $3, tender_id = $4, 
-			author_type = $5, author_id = $6, creator_username = $7, 
-			version = $8, created_at = $9, updated_at = $10
-		WHERE id = $11
+		SET name = $1, description = $2, price = $3, currency = $4, version = $5, updated_at = $6
+		WHERE id = $7 AND version = $8
 		RETURNING id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
+			author_id, creator_username, price, currency, version, created_at, updated_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare update statement: %w", err)
@@ -339,15 +437,12 @@ func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, ve
 	row := stmt.QueryRowContext(ctx,
 		historyBid.Name,
 		historyBid.Description,
-		historyBid.Status,
-		historyBid.TenderID,
-		historyBid.AuthorType,
-		historyBid.AuthorID,
-		historyBid.CreatorUsername,
-		historyBid.Version,
-		historyBid.CreatedAt,
+		historyBid.Price,
+		historyBid.Currency,
+		currentVersion+1,
 		time.Now(),
 		bidID,
+		currentVersion,
 	)
 
 	var updatedBid model.Bid
@@ -360,14 +455,27 @@ func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, ve
 		&updatedBid.AuthorType,
 		&updatedBid.AuthorID,
 		&updatedBid.CreatorUsername,
+		&updatedBid.Price,
+		&updatedBid.Currency,
 		&updatedBid.Version,
 		&updatedBid.CreatedAt,
 		&updatedBid.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update bid: %w", err)
 	}
 
+	event, err := model.NewBidEvent(updatedBid.Status, &updatedBid)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -375,10 +483,97 @@ func (r *bidRepository) RollbackBidVersion(ctx context.Context, bidID string, ve
 	return &updatedBid, nil
 }
 
-func (r *bidRepository) AddBidFeedback(ctx context.Context, bidID string, username string, review string) (*model.Bid, error) {
+func (r *bidRepository) CreateBidReview(ctx context.Context, review *model.BidReview) (*model.BidReview, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO bid_review (id, bid_id, author_username, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, bid_id, author_username, description, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var createdReview model.BidReview
+	err = stmt.QueryRowContext(ctx, review.ID, review.BidID, review.AuthorUsername, review.Description, review.CreatedAt, review.UpdatedAt).Scan(
+		&createdReview.ID,
+		&createdReview.BidID,
+		&createdReview.AuthorUsername,
+		&createdReview.Description,
+		&createdReview.CreatedAt,
+		&createdReview.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating bid review", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to insert bid review: %w", err)
+	}
+
+	return &createdReview, nil
+}
+
+// HasTenderBidByAuthor проверяет, что автор подал на тендер предложение, видимое организации тендера
+func (r *bidRepository) HasTenderBidByAuthor(ctx context.Context, tenderID string, authorUsername string) (bool, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM bid
+			WHERE tender_id = $1 AND creator_username = $2 AND status IN ('Published', 'Approved', 'Rejected')
+		)
+	`)
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var exists bool
+	if err := stmt.QueryRowContext(ctx, tenderID, authorUsername).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check tender bid author: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *bidRepository) GetBidReviews(ctx context.Context, authorUsername string, limit int, offset int) ([]model.BidReview, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT br.id, br.bid_id, br.author_username, br.description, br.created_at, br.updated_at
+		FROM bid_review br
+		JOIN bid b ON b.id = br.bid_id
+		WHERE b.creator_username = $1
+		ORDER BY br.created_at DESC
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, authorUsername, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting bid reviews", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []model.BidReview
+	for rows.Next() {
+		review := model.BidReview{}
+		err := rows.Scan(&review.ID, &review.BidID, &review.AuthorUsername, &review.Description, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// SubmitBidDecision записывает решение ответственного и, если оно итоговое, меняет статусы предложения и тендера.
+// Тендер и предложение блокируются до конца транзакции, поэтому одновременные решения проверяют кворум по очереди.
+// check проверяет заблокированные строки; возвращаемый тендер не nil, только если решение его закрыло
+func (r *bidRepository) SubmitBidDecision(ctx context.Context, decision *model.BidDecisionRecord, check func(*model.Tender, *model.Bid) error) (*model.Bid, *model.Tender, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(); err != nil {
@@ -388,39 +583,440 @@ func (r *bidRepository) AddBidFeedback(ctx context.Context, bidID string, userna
 		}
 	}()
 
-	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO bid_feedback (id, bid_id, username, review, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+	// Тендер блокируется первым, как и при ставках редукциона
+	var tender model.Tender
+	err = tx.QueryRowContext(ctx, `
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at
+		FROM tender t
+		JOIN bid b ON b.tender_id = t.id
+		WHERE b.id = $1
+		FOR UPDATE OF t
+	`, decision.BidID).Scan(
+		&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.OrganizationID,
+		&tender.CreatorUsername,
+		&tender.Status,
+		&tender.BudgetMin,
+		&tender.BudgetMax,
+		&tender.BudgetCurrency,
+		&tender.Deadline,
+		&tender.Auction,
+		&tender.AuctionEndsAt,
+		&tender.Version,
+		&tender.CreatedAt,
+		&tender.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.ErrBidNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to lock tender: %w", err)
+	}
+
+	var bid model.Bid
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
+		WHERE id = $1
+		FOR UPDATE
+	`, decision.BidID).Scan(
+		&bid.ID,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.TenderID,
+		&bid.AuthorType,
+		&bid.AuthorID,
+		&bid.CreatorUsername,
+		&bid.Price,
+		&bid.Currency,
+		&bid.Version,
+		&bid.CreatedAt,
+		&bid.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.ErrBidNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to lock bid: %w", err)
+	}
+
+	if err := check(&tender, &bid); err != nil {
+		return nil, nil, err
+	}
+
+	// Повторное решение того же ответственного не перезаписывает прежнее
+	var decisionID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bid_decision (id, bid_id, username, decision, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (bid_id, username) DO NOTHING
+		RETURNING id
+	`, decision.ID, decision.BidID, decision.Username, decision.Decision, decision.CreatedAt).Scan(&decisionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.ErrDecisionExists
+		}
+		return nil, nil, fmt.Errorf("failed to insert bid decision: %w", err)
+	}
+
+	var closedTender *model.Tender
+	if decision.Decision == model.BidDecisionRejected {
+		bid.Status = model.BidStatusRejected
+	} else {
+		var approvals, responsibles int
+		err = tx.QueryRowContext(ctx, `
+			SELECT
+				(SELECT COUNT(*) FROM bid_decision WHERE bid_id = $1 AND decision = 'Approved'),
+				(SELECT COUNT(*) FROM organization_responsible WHERE organization_id = $2)
+		`, bid.ID, tender.OrganizationID).Scan(&approvals, &responsibles)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count approvals: %w", err)
+		}
+
+		if !model.HasApprovalQuorum(approvals, responsibles) {
+			if err := tx.Commit(); err != nil {
+				return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			return &bid, nil, nil
+		}
+
+		bid.Status = model.BidStatusApproved
+		tender.Status = model.TenderStatusClosed
+		closedTender, err = updateTender(ctx, tx, &tender)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	updatedBid, err := r.updateBid(ctx, tx, &bid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updatedBid, closedTender, nil
+}
+
+func (r *bidRepository) GetBidDecisions(ctx context.Context, bidID string) ([]model.BidDecisionRecord, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, bid_id, username, decision, created_at
+		FROM bid_decision
+		WHERE bid_id = $1
+		ORDER BY created_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, uuid.New().String(), bidID, username, review, time.Now(), time.Now())
+	rows, err := stmt.QueryContext(ctx, bidID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert bid feedback: %w", err)
+		r.logger.ErrorContext(ctx, "Error getting bid decisions", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
+	defer rows.Close()
 
-	stmt, err = tx.PrepareContext(ctx, `
-		UPDATE bid
-		SET version = version + 1
+	var decisions []model.BidDecisionRecord
+	for rows.Next() {
+		decision := model.BidDecisionRecord{}
+		err := rows.Scan(&decision.ID, &decision.BidID, &decision.Username, &decision.Decision, &decision.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+func (r *bidRepository) GetBidVersions(ctx context.Context, bidID string, limit int, offset int) ([]model.Bid, error) {
+	// Текущая версия хранится в bid, предыдущие - в bid_history
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, status::text, tender_id, author_type::text, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
 		WHERE id = $1
+		UNION ALL
+		SELECT bid_id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid_history
+		WHERE bid_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting bid versions: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, bidID, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting bid versions", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting bid versions: %w", err)
+	}
+	defer rows.Close()
+
+	var bids []model.Bid
+	for rows.Next() {
+		bid := model.Bid{}
+		err := rows.Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Price, &bid.Currency, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bid version: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+
+	return bids, nil
+}
+
+func (r *bidRepository) GetBidVersion(ctx context.Context, bidID string, version int) (*model.Bid, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, status::text, tender_id, author_type::text, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
+		WHERE id = $1 AND version = $2
+		UNION ALL
+		SELECT bid_id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid_history
+		WHERE bid_id = $1 AND version = $2
+		LIMIT 1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting bid version: %w", err)
+	}
+	defer stmt.Close()
+
+	bid := model.Bid{}
+	err = stmt.QueryRowContext(ctx, bidID, version).Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.TenderID, &bid.AuthorType, &bid.AuthorID, &bid.CreatorUsername, &bid.Price, &bid.Currency, &bid.Version, &bid.CreatedAt, &bid.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error getting bid version", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting bid version: %w", err)
+	}
+
+	return &bid, nil
+}
+
+func (r *bidRepository) SearchBids(ctx context.Context, query string, tenderID string, limit int, offset int, username string) ([]model.BidSearchResult, error) {
+	// Видимость такая же, как в списке предложений тендера
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, b.author_id, b.creator_username, b.price, b.currency, b.version, b.created_at, b.updated_at,
+			ts_rank(b.search_vector, q.query) AS rank,
+			ts_headline('russian', b.name || ' ' || coalesce(b.description, ''), q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM bid b
+		JOIN tender t ON t.id = b.tender_id
+		CROSS JOIN (SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query) q
+		WHERE b.search_vector @@ q.query
+		AND ($2 = '' OR b.tender_id = $2)
+		AND (
+			b.creator_username = $5
+			OR (b.author_type = 'Organization' AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = b.author_id AND e.username = $5
+			))
+			OR (b.status IN ('Published', 'Approved', 'Rejected') AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $5
+			))
+		)
+		ORDER BY rank DESC, b.id
+		LIMIT $3 OFFSET $4
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, bidID)
+	rows, err := stmt.QueryContext(ctx, query, tenderID, limit, offset, username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update bid version: %w", err)
+		r.logger.ErrorContext(ctx, "Error searching bids", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.BidSearchResult
+	for rows.Next() {
+		result := model.BidSearchResult{}
+		err := rows.Scan(&result.ID, &result.Name, &result.Description, &result.Status, &result.TenderID, &result.AuthorType, &result.AuthorID, &result.CreatorUsername, &result.Price, &result.Currency, &result.Version, &result.CreatedAt, &result.UpdatedAt, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (r *bidRepository) SaveBidScores(ctx context.Context, scores []model.BidScore) ([]model.BidScore, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO bid_score (id, bid_id, criterion_id, username, score, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (bid_id, criterion_id, username) DO UPDATE
+		SET score = EXCLUDED.score, created_at = EXCLUDED.created_at
+		RETURNING id, bid_id, criterion_id, username, score, created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var saved []model.BidScore
+	for _, score := range scores {
+		var savedScore model.BidScore
+		err := stmt.QueryRowContext(ctx, score.ID, score.BidID, score.CriterionID, score.Username, score.Score, score.CreatedAt).Scan(
+			&savedScore.ID,
+			&savedScore.BidID,
+			&savedScore.CriterionID,
+			&savedScore.Username,
+			&savedScore.Score,
+			&savedScore.CreatedAt,
+		)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error saving bid score", slog.Any("error", err))
+			return nil, fmt.Errorf("failed to save bid score: %w", err)
+		}
+		saved = append(saved, savedScore)
 	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return r.GetBidById(ctx, bidID)
+	return saved, nil
+}
+
+func (r *bidRepository) GetBidScores(ctx context.Context, bidID string) ([]model.BidScore, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, bid_id, criterion_id, username, score, created_at
+		FROM bid_score
+		WHERE bid_id = $1
+		ORDER BY criterion_id, username
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, bidID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting bid scores", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []model.BidScore
+	for rows.Next() {
+		score := model.BidScore{}
+		err := rows.Scan(&score.ID, &score.BidID, &score.CriterionID, &score.Username, &score.Score, &score.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
+// PlaceAuctionPrice принимает цену только ниже текущего минимума среди опубликованных предложений
+func (r *bidRepository) PlaceAuctionPrice(ctx context.Context, bid *model.Bid, now time.Time, extension time.Duration) (*model.Bid, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	// Блокировка тендера выстраивает одновременные ставки в очередь
+	var endsAt time.Time
+	var deadline *time.Time
+	var currency string
+	err = tx.QueryRowContext(ctx, `
+		SELECT auction_ends_at, deadline, budget_currency
+		FROM tender
+		WHERE id = $1 AND auction
+		FOR UPDATE
+	`, bid.TenderID).Scan(&endsAt, &deadline, &currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrNotAuction
+		}
+		return nil, fmt.Errorf("failed to lock tender: %w", err)
+	}
+
+	if !now.Before(endsAt) {
+		return nil, model.ErrAuctionClosed
+	}
+
+	// Цены в другой валюте несравнимы с текущим минимумом
+	if bid.Currency == nil || *bid.Currency != currency {
+		return nil, model.ErrCurrencyMismatch
+	}
 
+	var lowest *model.Decimal
+	err = tx.QueryRowContext(ctx, `
+		SELECT MIN(price)
+		FROM bid
+		WHERE tender_id = $1 AND status = 'Published' AND currency = $2
+	`, bid.TenderID, currency).Scan(&lowest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lowest auction price: %w", err)
+	}
+
+	if lowest != nil && bid.Price.Cmp(*lowest) >= 0 {
+		return nil, model.ErrAuctionPriceTooHigh
+	}
+
+	updatedBid, err := r.updateBid(ctx, tx, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ставка в последние минуты продлевает торги, чтобы остальные участники успели ответить, но не дальше дедлайна
+	extendedEndsAt := now.Add(extension)
+	if deadline != nil && deadline.Before(extendedEndsAt) {
+		extendedEndsAt = *deadline
+	}
+	if extendedEndsAt.After(endsAt) {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE tender
+			SET auction_ends_at = $2
+			WHERE id = $1
+		`, bid.TenderID, extendedEndsAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extend auction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updatedBid, nil
 }
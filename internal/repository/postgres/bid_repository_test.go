@@ -5,6 +5,7 @@ import (
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"regexp"
 	"testing"
@@ -33,6 +34,7 @@ func TestCreateBid(t *testing.T) {
 		defer db.Close()
 
 		ctx := context.Background()
+		price, currency := model.Decimal("1500.50"), "RUB"
 		bidRequest := &model.Bid{
 			ID:              uuid.New().String(),
 			Name:            "Test Bid",
@@ -42,6 +44,8 @@ func TestCreateBid(t *testing.T) {
 			AuthorType:      "user",
 			AuthorID:        uuid.New().String(),
 			CreatorUsername: "testuser",
+			Price:           &price,
+			Currency:        &currency,
 			Version:         1,
 			CreatedAt:       time.Now(),
 			UpdatedAt:       time.Now(),
@@ -49,13 +53,14 @@ func TestCreateBid(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 	`)).ExpectQuery().WithArgs(
-			bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt,
-		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at"}).
-			AddRow(bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt))
+			bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, price, currency, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt,
+		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at"}).
+			AddRow(bidRequest.ID, bidRequest.Name, bidRequest.Description, bidRequest.Status, bidRequest.TenderID, bidRequest.AuthorType, bidRequest.AuthorID, bidRequest.CreatorUsername, []byte("1500.50"), currency, bidRequest.Version, bidRequest.CreatedAt, bidRequest.UpdatedAt))
+		expectInsertEvent(mock, model.EventBidCreated, bidRequest.ID, bidRequest.TenderID)
 		mock.ExpectCommit()
 
 		bid, err := repo.CreateBid(ctx, bidRequest)
@@ -69,6 +74,8 @@ func TestCreateBid(t *testing.T) {
 		assert.Equal(t, bidRequest.AuthorType, bid.AuthorType)
 		assert.Equal(t, bidRequest.AuthorID, bid.AuthorID)
 		assert.Equal(t, bidRequest.CreatorUsername, bid.CreatorUsername)
+		assert.Equal(t, price, *bid.Price)
+		assert.Equal(t, currency, *bid.Currency)
 		assert.Equal(t, bidRequest.Version, bid.Version)
 		assert.WithinDuration(t, bidRequest.CreatedAt, bid.CreatedAt, time.Second)
 		assert.WithinDuration(t, bidRequest.UpdatedAt, bid.UpdatedAt, time.Second)
@@ -100,9 +107,9 @@ func TestCreateBid(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 	`)).ExpectQuery().WillReturnError(sql.ErrConnDone)
 		mock.ExpectRollback()
 
@@ -126,12 +133,12 @@ func TestGetBidById(t *testing.T) {
 		ctx := context.Background()
 
 		id := uuid.New().String()
-		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE id = $1`)).ExpectQuery().WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
 		}).AddRow(
-			id, "Test Bid", "Test Description", "open", uuid.New().String(), "user", uuid.New().String(), "testuser", 1, time.Now(), time.Now(),
+			id, "Test Bid", "Test Description", "open", uuid.New().String(), "user", uuid.New().String(), "testuser", nil, nil, 1, time.Now(), time.Now(),
 		))
 
 		bid, err := repo.GetBidById(ctx, id)
@@ -151,7 +158,7 @@ func TestGetBidById(t *testing.T) {
 		ctx := context.Background()
 
 		id := uuid.New().String()
-		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE id = $1`)).ExpectQuery().WithArgs(id).WillReturnError(sql.ErrConnDone)
 
@@ -172,10 +179,10 @@ func TestGetBidById(t *testing.T) {
 		ctx := context.Background()
 
 		id := uuid.New().String()
-		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE id = $1`)).ExpectQuery().WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
 		}))
 
 		bid, err := repo.GetBidById(ctx, id)
@@ -200,17 +207,19 @@ func TestGetBidByUsername(t *testing.T) {
 		offset := 0
 
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE creator_username = $1
+		AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+		ORDER BY name, id
 		LIMIT $2 OFFSET $3
-		`)).ExpectQuery().WithArgs(username, limit, offset).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
+		`)).ExpectQuery().WithArgs(username, limit, offset, nil, nil).WillReturnRows(sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
 		}).AddRow(
-			uuid.New().String(), "Test Bid", "Test Description", "open", uuid.New().String(), "user", uuid.New().String(), username, 1, time.Now(), time.Now(),
+			uuid.New().String(), "Test Bid", "Test Description", "open", uuid.New().String(), "user", uuid.New().String(), username, nil, nil, 1, time.Now(), time.Now(),
 		))
 
-		bids, err := repo.GetBidByUsername(ctx, limit, offset, username)
+		bids, err := repo.GetBidByUsername(ctx, limit, offset, nil, username)
 		assert.NoError(t, err)
 		assert.NotNil(t, bids)
 		assert.Len(t, bids, 1)
@@ -231,13 +240,15 @@ func TestGetBidByUsername(t *testing.T) {
 		offset := 0
 
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE creator_username = $1
+		AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+		ORDER BY name, id
 		LIMIT $2 OFFSET $3
-		`)).ExpectQuery().WithArgs(username, limit, offset).WillReturnError(sql.ErrConnDone)
+		`)).ExpectQuery().WithArgs(username, limit, offset, nil, nil).WillReturnError(sql.ErrConnDone)
 
-		bids, err := repo.GetBidByUsername(ctx, limit, offset, username)
+		bids, err := repo.GetBidByUsername(ctx, limit, offset, nil, username)
 		assert.Error(t, err)
 		assert.Nil(t, bids)
 
@@ -257,13 +268,15 @@ func TestGetBidByUsername(t *testing.T) {
 		offset := 0
 
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
 		FROM bid
 		WHERE creator_username = $1
+		AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+		ORDER BY name, id
 		LIMIT $2 OFFSET $3
-		`)).ExpectQuery().WithArgs(username, limit, offset).WithArgs(username, limit, offset).WillReturnRows(sqlmock.NewRows([]string{}))
+		`)).ExpectQuery().WithArgs(username, limit, offset, nil, nil).WillReturnRows(sqlmock.NewRows([]string{}))
 
-		bids, err := repo.GetBidByUsername(ctx, limit, offset, username)
+		bids, err := repo.GetBidByUsername(ctx, limit, offset, nil, username)
 		assert.Nil(t, bids)
 		assert.Len(t, bids, 0)
 
@@ -273,29 +286,70 @@ func TestGetBidByUsername(t *testing.T) {
 		}
 	})
 }
-func TestGetBidStatus(t *testing.T) {
+func TestGetTenderBids(t *testing.T) {
+	tenderBidsQuery := func(sortColumn string, comparison string, castType string, order string) string {
+		return regexp.QuoteMeta(fmt.Sprintf(`
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, b.author_id, b.creator_username, b.price, b.currency, b.version, b.created_at, b.updated_at, bs.score
+		FROM bid b
+		JOIN tender t ON t.id = b.tender_id
+		LEFT JOIN LATERAL (
+			SELECT CASE WHEN COUNT(s.criterion_id) = 0 THEN NULL
+				ELSE ROUND(SUM(c.weight * COALESCE(s.score, 0)) / SUM(c.weight), 4) END AS score
+			FROM tender_criterion c
+			LEFT JOIN (
+				SELECT criterion_id, AVG(score) AS score
+				FROM bid_score
+				WHERE bid_id = b.id
+				GROUP BY criterion_id
+			) s ON s.criterion_id = c.id
+			WHERE c.tender_id = b.tender_id AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $4
+			)
+		) bs ON true
+		WHERE b.tender_id = $1
+		AND (
+			b.creator_username = $4
+			OR (b.author_type = 'Organization' AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = b.author_id AND e.username = $4
+			))
+			OR (b.status IN ('Published', 'Approved', 'Rejected') AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $4
+			))
+		)
+		AND ($5::text IS NULL OR (%[1]s, b.id) %[2]s ($5::%[3]s, $6))
+		ORDER BY %[1]s %[4]s, b.id %[4]s
+		LIMIT $2 OFFSET $3
+	`, sortColumn, comparison, castType, order))
+	}
+	query := tenderBidsQuery("b.name", ">", "varchar", "ASC")
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, repo := setupTestBid(t)
 		defer db.Close()
 
 		ctx := context.Background()
-		bidID := uuid.New().String()
-		expectedStatus := model.BidStatus("open")
+		tenderID := uuid.New().String()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT status
-		FROM bid
-		WHERE id = $1
-		`)).ExpectQuery().WithArgs(bidID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus))
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID, 5, 0, "ivanov", nil, nil).WillReturnRows(sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at", "score",
+		}).
+			AddRow(uuid.New().String(), "Bid", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 1, time.Now(), time.Now(), nil))
 
-		status, err := repo.GetBidStatus(ctx, bidID)
+		bids, err := repo.GetTenderBids(ctx, tenderID, 5, 0, nil, model.BidSort{Field: model.BidSortName}, "ivanov")
 		assert.NoError(t, err)
-		assert.Equal(t, expectedStatus, status)
+		assert.Len(t, bids, 1)
+		assert.Equal(t, model.BidStatusPublished, bids[0].Status)
 
-		mock.ExpectationsWereMet()
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -303,48 +357,113 @@ func TestGetBidStatus(t *testing.T) {
 		defer db.Close()
 
 		ctx := context.Background()
-		bidID := uuid.New().String()
+		tenderID := uuid.New().String()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT status
-		FROM bid
-		WHERE id = $1
-		`)).ExpectQuery().WithArgs(bidID).WillReturnError(sql.ErrConnDone)
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID, 5, 0, "ivanov", nil, nil).WillReturnError(sql.ErrConnDone)
 
-		status, err := repo.GetBidStatus(ctx, bidID)
+		bids, err := repo.GetTenderBids(ctx, tenderID, 5, 0, nil, model.BidSort{Field: model.BidSortName}, "ivanov")
 		assert.Error(t, err)
-		assert.Equal(t, model.BidStatus(""), status)
+		assert.Nil(t, bids)
 
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("not_found", func(t *testing.T) {
+	t.Run("with_cursor", func(t *testing.T) {
 		db, mock, repo := setupTestBid(t)
 		defer db.Close()
 
 		ctx := context.Background()
-		bidID := uuid.New().String()
+		tenderID := uuid.New().String()
+		cursor := &model.Cursor{Value: "Bid A", ID: "bid-a"}
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT status
-		FROM bid
-		WHERE id = $1
-		`)).ExpectQuery().WithArgs(bidID).WillReturnRows(sqlmock.NewRows([]string{}))
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID, 5, 0, "ivanov", "Bid A", "bid-a").WillReturnRows(sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at", "score",
+		}).
+			AddRow("bid-b", "Bid B", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 1, time.Now(), time.Now(), nil))
 
-		status, err := repo.GetBidStatus(ctx, bidID)
-		assert.Error(t, err)
-		assert.Equal(t, model.BidStatus(""), status)
+		bids, err := repo.GetTenderBids(ctx, tenderID, 5, 0, cursor, model.BidSort{Field: model.BidSortName}, "ivanov")
+		assert.NoError(t, err)
+		assert.Len(t, bids, 1)
+		assert.Equal(t, "Bid B", bids[0].Name)
 
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("sort_by_price_desc", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		tenderID := uuid.New().String()
+		cursor := &model.Cursor{Value: "2000.00", ID: "bid-a"}
+
+		mock.ExpectPrepare(tenderBidsQuery("COALESCE(b.price, '-Infinity'::numeric)", "<", "numeric", "DESC")).ExpectQuery().
+			WithArgs(tenderID, 5, 0, "ivanov", "2000.00", "bid-a").
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at", "score",
+			}).
+				AddRow("bid-b", "Bid B", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "petrov", "petrov", []byte("1500.00"), "RUB", 1, time.Now(), time.Now(), nil).
+				AddRow("bid-c", "Bid C", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "sidorov", "sidorov", nil, nil, 1, time.Now(), time.Now(), nil))
+
+		bids, err := repo.GetTenderBids(ctx, tenderID, 5, 0, cursor, model.BidSort{Field: model.BidSortPrice, Desc: true}, "ivanov")
+		assert.NoError(t, err)
+		assert.Len(t, bids, 2)
+		assert.Equal(t, model.Decimal("1500.00"), *bids[0].Price)
+		assert.Nil(t, bids[1].Price)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("sort_by_score_desc", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		tenderID := uuid.New().String()
+
+		mock.ExpectPrepare(tenderBidsQuery("COALESCE(bs.score, '-Infinity'::numeric)", "<", "numeric", "DESC")).ExpectQuery().
+			WithArgs(tenderID, 5, 0, "ivanov", nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at", "score",
+			}).
+				AddRow("bid-a", "Bid A", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 1, time.Now(), time.Now(), []byte("8.2500")).
+				AddRow("bid-b", "Bid B", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "sidorov", "sidorov", nil, nil, 1, time.Now(), time.Now(), nil))
+
+		bids, err := repo.GetTenderBids(ctx, tenderID, 5, 0, nil, model.BidSort{Field: model.BidSortScore, Desc: true}, "ivanov")
+		assert.NoError(t, err)
+		assert.Len(t, bids, 2)
+		assert.Equal(t, 8.25, *bids[0].Score)
+		assert.Nil(t, bids[1].Score)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
 func TestUpdateBid(t *testing.T) {
+	historyQuery := regexp.QuoteMeta(`
+		INSERT INTO bid_history (
+			id, bid_id, name, description, status, tender_id, 
+			author_type, author_id, creator_username, price, currency, 
+			version, created_at, updated_at
+		)
+		SELECT $1, id, name, description, status, tender_id,
+			author_type, author_id, creator_username, price, currency,
+			version, created_at, updated_at
+		FROM bid
+		WHERE id = $2 AND version = $3
+		RETURNING status
+	`)
+	updateQuery := regexp.QuoteMeta(`
+		UPDATE bid 
+		SET name = $1, description = $2, status = $3, tender_id = $4, 
+			author_type = $5, author_id = $6, creator_username = $7, 
+			price = $8, currency = $9, 
+			version = $10, created_at = $11, updated_at = $12 
+		WHERE id = $13 AND version = $14
+		RETURNING id, name, description, status, tender_id, author_type, 
+			author_id, creator_username, price, currency, version, created_at, updated_at
+	`)
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, repo := setupTestBid(t)
 		defer db.Close()
@@ -369,27 +488,14 @@ func TestUpdateBid(t *testing.T) {
 		updatedBid.UpdatedAt = time.Now()
 
 		mock.ExpectBegin()
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		UPDATE bid 
-		SET name = $1, description = $2, status = $3, tender_id = $4, 
-			author_type = $5, author_id = $6, creator_username = $7, 
-			version = $8, created_at = $9, updated_at = $10 
-		WHERE id = $11
-		RETURNING id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-	`)).ExpectQuery().WithArgs(
-			bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID, bid.CreatorUsername, bid.Version+1, bid.CreatedAt, sqlmock.AnyArg(), bid.ID,
-		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at"}).
-			AddRow(updatedBid.ID, updatedBid.Name, updatedBid.Description, updatedBid.Status, updatedBid.TenderID, updatedBid.AuthorType, updatedBid.AuthorID, updatedBid.CreatorUsername, updatedBid.Version, updatedBid.CreatedAt, updatedBid.UpdatedAt))
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		INSERT INTO bid_history (
-			id, bid_id, name, description, status, tender_id, 
-			author_type, author_id, creator_username, 
-			version, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	`)).ExpectExec().WithArgs(
-			sqlmock.AnyArg(), bid.ID, bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID, bid.CreatorUsername, bid.Version, bid.CreatedAt, sqlmock.AnyArg(),
-		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WithArgs(
+			sqlmock.AnyArg(), bid.ID, bid.Version,
+		).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("open"))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WithArgs(
+			bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID, bid.CreatorUsername, nil, nil, bid.Version+1, bid.CreatedAt, sqlmock.AnyArg(), bid.ID, bid.Version,
+		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at"}).
+			AddRow(updatedBid.ID, updatedBid.Name, updatedBid.Description, updatedBid.Status, updatedBid.TenderID, updatedBid.AuthorType, updatedBid.AuthorID, updatedBid.CreatorUsername, nil, nil, updatedBid.Version, updatedBid.CreatedAt, updatedBid.UpdatedAt))
+		expectInsertEvent(mock, model.EventBidUpdated, bid.ID, bid.TenderID)
 		mock.ExpectCommit()
 
 		result, err := repo.UpdateBid(ctx, bid)
@@ -407,10 +513,7 @@ func TestUpdateBid(t *testing.T) {
 		assert.WithinDuration(t, updatedBid.CreatedAt, result.CreatedAt, time.Second)
 		assert.WithinDuration(t, updatedBid.UpdatedAt, result.UpdatedAt, time.Second)
 
-		mock.ExpectationsWereMet()
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -433,28 +536,107 @@ func TestUpdateBid(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		UPDATE bid 
-		SET name = $1, description = $2, status = $3, tender_id = $4, 
-			author_type = $5, author_id = $6, creator_username = $7, 
-			version = $8, created_at = $9, updated_at = $10 
-		WHERE id = $11
-		RETURNING id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-	`)).ExpectQuery().WillReturnError(sql.ErrConnDone)
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("open"))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WillReturnError(sql.ErrConnDone)
 		mock.ExpectRollback()
 
 		result, err := repo.UpdateBid(ctx, bid)
 		assert.Error(t, err)
 		assert.Nil(t, result)
 
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("status_change_event", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		bid := &model.Bid{ID: uuid.New().String(), Name: "Test Bid", Status: model.BidStatusApproved, TenderID: uuid.New().String(), Version: 1}
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at"}).
+			AddRow(bid.ID, bid.Name, "", model.BidStatusApproved, bid.TenderID, model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 2, time.Now(), time.Now()))
+		// Переход Published -> Approved публикуется как BidApproved
+		expectInsertEvent(mock, model.EventBidApproved, bid.ID, bid.TenderID)
+		mock.ExpectCommit()
+
+		_, err := repo.UpdateBid(context.Background(), bid)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("history_failure", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		bid := &model.Bid{ID: uuid.New().String(), Name: "Test Bid", Version: 1}
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		result, err := repo.UpdateBid(ctx, bid)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to insert bid history")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("version_conflict", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		bid := &model.Bid{ID: uuid.New().String(), Name: "Test Bid", Version: 2}
+
+		mock.ExpectBegin()
+		// Версия уже изменилась - история не записывается, до обновления дело не доходит
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}))
+		mock.ExpectRollback()
+
+		result, err := repo.UpdateBid(ctx, bid)
+		assert.Nil(t, result)
+		assert.Equal(t, model.ErrVersionConflict, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
 func TestRollbackBidVersion(t *testing.T) {
+	historyQuery := regexp.QuoteMeta(`
+		SELECT bid_id, name, description, status, tender_id, author_type, 
+			author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid_history
+		WHERE bid_id = $1 AND version = $2
+	`)
+	snapshotQuery := regexp.QuoteMeta(`
+		INSERT INTO bid_history (
+			id, bid_id, name, description, status, tender_id, 
+			author_type, author_id, creator_username, price, currency, 
+			version, created_at, updated_at
+		)
+		SELECT $1, id, name, description, status, tender_id,
+			author_type, author_id, creator_username, price, currency,
+			version, created_at, updated_at
+		FROM bid
+		WHERE id = $2
+		RETURNING version
+	`)
+	updateQuery := regexp.QuoteMeta(`
+		UPDATE bid
+		SET name = $1, description = $2, price = $3, currency = $4, version = $5, updated_at = $6
+		WHERE id = $7 AND version = $8
+		RETURNING id, name, description, status, tender_id, author_type, 
+			author_id, creator_username, price, currency, version, created_at, updated_at
+	`)
+	bidColumns := []string{
+		"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
+	}
+	noCheck := func(*model.Bid) error { return nil }
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, repo := setupTestBid(t)
 		defer db.Close()
@@ -464,12 +646,12 @@ func TestRollbackBidVersion(t *testing.T) {
 		version := 1
 
 		historyBid := &model.Bid{
-			ID:              uuid.New().String(),
+			ID:              bidID,
 			Name:            "Test Bid",
 			Description:     "Test Description",
-			Status:          "open",
+			Status:          "Created",
 			TenderID:        uuid.New().String(),
-			AuthorType:      "user",
+			AuthorType:      "User",
 			AuthorID:        uuid.New().String(),
 			CreatorUsername: "testuser",
 			Version:         version,
@@ -478,53 +660,30 @@ func TestRollbackBidVersion(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-		FROM bid_history
-		WHERE bid_id = $1 AND version = $2
-		`)).WithArgs(bidID, version).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
-		}).AddRow(
-			historyBid.ID, historyBid.Name, historyBid.Description, historyBid.Status, historyBid.TenderID, historyBid.AuthorType, historyBid.AuthorID, historyBid.CreatorUsername, historyBid.Version, historyBid.CreatedAt, historyBid.UpdatedAt,
+		mock.ExpectQuery(historyQuery).WithArgs(bidID, version).WillReturnRows(sqlmock.NewRows(bidColumns).AddRow(
+			historyBid.ID, historyBid.Name, historyBid.Description, historyBid.Status, historyBid.TenderID, historyBid.AuthorType, historyBid.AuthorID, historyBid.CreatorUsername, []byte("990.00"), "USD", historyBid.Version, historyBid.CreatedAt, historyBid.UpdatedAt,
 		))
-
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		UPDATE bid
-		SET name = $1, description = $2, status = $3, tender_id = $4, 
-			author_type = $5, author_id = $6, creator_username = $7, 
-			version = $8, created_at = $9, updated_at = $10
-		WHERE id = $11
-		RETURNING id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-		`)).ExpectQuery().WithArgs(
-			historyBid.Name, historyBid.Description, historyBid.Status, historyBid.TenderID, historyBid.AuthorType, historyBid.AuthorID, historyBid.CreatorUsername, historyBid.Version, historyBid.CreatedAt, sqlmock.AnyArg(), bidID,
-		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
-		}).AddRow(
-			historyBid.ID, historyBid.Name, historyBid.Description, historyBid.Status, historyBid.TenderID, historyBid.AuthorType, historyBid.AuthorID, historyBid.CreatorUsername, historyBid.Version, historyBid.CreatedAt, time.Now(),
+		mock.ExpectQuery(snapshotQuery).WithArgs(sqlmock.AnyArg(), bidID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WithArgs(
+			historyBid.Name, historyBid.Description, "990.00", "USD", 3, sqlmock.AnyArg(), bidID, 2,
+		).WillReturnRows(sqlmock.NewRows(bidColumns).AddRow(
+			bidID, historyBid.Name, historyBid.Description, "Published", historyBid.TenderID, historyBid.AuthorType, historyBid.AuthorID, historyBid.CreatorUsername, []byte("990.00"), "USD", 3, historyBid.CreatedAt, time.Now(),
 		))
-
+		expectInsertEvent(mock, model.EventBidUpdated, bidID, historyBid.TenderID)
 		mock.ExpectCommit()
 
-		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version)
+		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version, noCheck)
 		assert.NoError(t, err)
 		assert.NotNil(t, updatedBid)
-		assert.Equal(t, historyBid.ID, updatedBid.ID)
+		assert.Equal(t, bidID, updatedBid.ID)
 		assert.Equal(t, historyBid.Name, updatedBid.Name)
 		assert.Equal(t, historyBid.Description, updatedBid.Description)
-		assert.Equal(t, historyBid.Status, updatedBid.Status)
-		assert.Equal(t, historyBid.TenderID, updatedBid.TenderID)
-		assert.Equal(t, historyBid.AuthorType, updatedBid.AuthorType)
-		assert.Equal(t, historyBid.AuthorID, updatedBid.AuthorID)
-		assert.Equal(t, historyBid.CreatorUsername, updatedBid.CreatorUsername)
-		assert.Equal(t, historyBid.Version, updatedBid.Version)
+		assert.Equal(t, model.BidStatus("Published"), updatedBid.Status)
+		assert.Equal(t, model.Decimal("990.00"), *updatedBid.Price)
+		assert.Equal(t, 3, updatedBid.Version)
 		assert.WithinDuration(t, historyBid.CreatedAt, updatedBid.CreatedAt, time.Second)
-
-		mock.ExpectationsWereMet()
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -536,22 +695,13 @@ func TestRollbackBidVersion(t *testing.T) {
 		version := 1
 
 		mock.ExpectBegin()
-		mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-		FROM bid_history
-		WHERE bid_id = $1 AND version = $2
-		`)).WithArgs(bidID, version).WillReturnError(sql.ErrConnDone)
+		mock.ExpectQuery(historyQuery).WithArgs(bidID, version).WillReturnError(sql.ErrConnDone)
 		mock.ExpectRollback()
 
-		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version)
-		assert.Error(t, err)
+		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version, noCheck)
+		assert.ErrorIs(t, err, sql.ErrConnDone)
 		assert.Nil(t, updatedBid)
-
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("not_found", func(t *testing.T) {
@@ -563,65 +713,172 @@ func TestRollbackBidVersion(t *testing.T) {
 		version := 1
 
 		mock.ExpectBegin()
-		mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, 
-			author_id, creator_username, version, created_at, updated_at
-		FROM bid_history
-		WHERE bid_id = $1 AND version = $2
-		`)).WithArgs(bidID, version).WillReturnRows(sqlmock.NewRows([]string{}))
+		mock.ExpectQuery(historyQuery).WithArgs(bidID, version).WillReturnRows(sqlmock.NewRows(bidColumns))
 		mock.ExpectRollback()
 
-		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version)
-		assert.Error(t, err)
+		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version, noCheck)
+		assert.ErrorIs(t, err, model.ErrVersionNotFound)
 		assert.Nil(t, updatedBid)
-
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
-}
-func TestAddBidFeedback(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+
+	t.Run("check_failed", func(t *testing.T) {
 		db, mock, repo := setupTestBid(t)
 		defer db.Close()
 
 		ctx := context.Background()
 		bidID := uuid.New().String()
-		username := "testuser"
-		review := "Great bid!"
+		version := 1
+		now := time.Now()
 
 		mock.ExpectBegin()
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		INSERT INTO bid_feedback (id, bid_id, username, review, created_at, updated_at)
+		mock.ExpectQuery(historyQuery).WithArgs(bidID, version).WillReturnRows(sqlmock.NewRows(bidColumns).AddRow(
+			bidID, "Test Bid", "Test Description", "Created", uuid.New().String(), "User", uuid.New().String(), "testuser", []byte("990.00"), "USD", version, now, now,
+		))
+		mock.ExpectRollback()
+
+		updatedBid, err := repo.RollbackBidVersion(ctx, bidID, version, func(restored *model.Bid) error {
+			assert.Equal(t, "USD", *restored.Currency)
+			return model.ErrCurrencyMismatch
+		})
+		assert.ErrorIs(t, err, model.ErrCurrencyMismatch)
+		assert.Nil(t, updatedBid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateBidReview(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO bid_review (id, bid_id, author_username, description, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		`)).ExpectExec().WithArgs(sqlmock.AnyArg(), bidID, username, review, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		UPDATE bid
-		SET version = version + 1
-		WHERE id = $1
-		`)).ExpectExec().WithArgs(bidID).WillReturnResult(sqlmock.NewResult(1, 1))
-		mock.ExpectCommit()
+		RETURNING id, bid_id, author_username, description, created_at, updated_at
+	`)
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, version, created_at, updated_at
-		FROM bid
-		WHERE id = $1`)).ExpectQuery().WithArgs(bidID).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "version", "created_at", "updated_at",
-		}).AddRow(
-			bidID, "Test Bid", "Test Description", "open", uuid.New().String(), "user", uuid.New().String(), username, 1, time.Now(), time.Now(),
-		))
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		review := &model.BidReview{
+			ID:             uuid.New().String(),
+			BidID:          uuid.New().String(),
+			AuthorUsername: "testuser",
+			Description:    "Great bid!",
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(
+			review.ID, review.BidID, review.AuthorUsername, review.Description, review.CreatedAt, review.UpdatedAt,
+		).WillReturnRows(sqlmock.NewRows([]string{"id", "bid_id", "author_username", "description", "created_at", "updated_at"}).
+			AddRow(review.ID, review.BidID, review.AuthorUsername, review.Description, review.CreatedAt, review.UpdatedAt))
 
-		bid, err := repo.AddBidFeedback(ctx, bidID, username, review)
+		createdReview, err := repo.CreateBidReview(ctx, review)
 		assert.NoError(t, err)
-		assert.NotNil(t, bid)
-		assert.Equal(t, bidID, bid.ID)
-		assert.Equal(t, username, bid.CreatorUsername)
+		assert.NotNil(t, createdReview)
+		assert.Equal(t, review.ID, createdReview.ID)
+		assert.Equal(t, review.BidID, createdReview.BidID)
+		assert.Equal(t, review.AuthorUsername, createdReview.AuthorUsername)
+		assert.Equal(t, review.Description, createdReview.Description)
 
-		mock.ExpectationsWereMet()
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		review := &model.BidReview{
+			ID:             uuid.New().String(),
+			BidID:          uuid.New().String(),
+			AuthorUsername: "testuser",
+			Description:    "Great bid!",
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
 		}
+
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrConnDone)
+
+		createdReview, err := repo.CreateBidReview(ctx, review)
+		assert.Error(t, err)
+		assert.Nil(t, createdReview)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestHasTenderBidByAuthor(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM bid
+			WHERE tender_id = $1 AND creator_username = $2 AND status IN ('Published', 'Approved', 'Rejected')
+		)
+	`)
+
+	t.Run("exists", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		tenderID := uuid.New().String()
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID, "testuser").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		exists, err := repo.HasTenderBidByAuthor(context.Background(), tenderID, "testuser")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not exists", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		tenderID := uuid.New().String()
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID, "testuser").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		exists, err := repo.HasTenderBidByAuthor(context.Background(), tenderID, "testuser")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBidReviews(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT br.id, br.bid_id, br.author_username, br.description, br.created_at, br.updated_at
+		FROM bid_review br
+		JOIN bid b ON b.id = br.bid_id
+		WHERE b.creator_username = $1
+		ORDER BY br.created_at DESC
+		LIMIT $2 OFFSET $3
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		authorUsername := "testuser"
+		limit := 10
+		offset := 0
+		reviewID := uuid.New().String()
+		createdAt := time.Now()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(authorUsername, limit, offset).WillReturnRows(sqlmock.NewRows([]string{
+			"id", "bid_id", "author_username", "description", "created_at", "updated_at",
+		}).AddRow(reviewID, uuid.New().String(), "reviewer", "Great bid!", createdAt, createdAt))
+
+		reviews, err := repo.GetBidReviews(ctx, authorUsername, limit, offset)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+		assert.Equal(t, reviewID, reviews[0].ID)
+		assert.Equal(t, "Great bid!", reviews[0].Description)
+		assert.WithinDuration(t, createdAt, reviews[0].CreatedAt, time.Second)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -629,24 +886,659 @@ func TestAddBidFeedback(t *testing.T) {
 		defer db.Close()
 
 		ctx := context.Background()
-		bidID := uuid.New().String()
-		username := "testuser"
-		review := "Great bid!"
 
-		mock.ExpectBegin()
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-		INSERT INTO bid_feedback (id, bid_id, username, review, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		`)).ExpectExec().WillReturnError(sql.ErrConnDone)
-		mock.ExpectRollback()
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("testuser", 10, 0).WillReturnError(sql.ErrConnDone)
 
-		bid, err := repo.AddBidFeedback(ctx, bidID, username, review)
+		reviews, err := repo.GetBidReviews(ctx, "testuser", 10, 0)
 		assert.Error(t, err)
-		assert.Nil(t, bid)
+		assert.Nil(t, reviews)
 
-		mock.ExpectationsWereMet()
-		if err == nil {
-			t.Errorf("expected error, got nothing")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubmitBidDecision(t *testing.T) {
+	lockTenderQuery := regexp.QuoteMeta(`
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at
+		FROM tender t
+		JOIN bid b ON b.tender_id = t.id
+		WHERE b.id = $1
+		FOR UPDATE OF t
+	`)
+	lockBidQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
+		WHERE id = $1
+		FOR UPDATE
+	`)
+	insertQuery := regexp.QuoteMeta(`
+		INSERT INTO bid_decision (id, bid_id, username, decision, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (bid_id, username) DO NOTHING
+		RETURNING id
+	`)
+	quorumQuery := regexp.QuoteMeta(`
+			SELECT
+				(SELECT COUNT(*) FROM bid_decision WHERE bid_id = $1 AND decision = 'Approved'),
+				(SELECT COUNT(*) FROM organization_responsible WHERE organization_id = $2)
+		`)
+	tenderColumns := []string{"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at"}
+	bidColumns := []string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at"}
+	now := time.Now()
+
+	newDecision := func(decision model.BidDecision) *model.BidDecisionRecord {
+		return &model.BidDecisionRecord{ID: "decision-1", BidID: "bid-1", Username: "ivanov", Decision: decision, CreatedAt: now}
+	}
+	expectLocks := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockTenderQuery).WithArgs("bid-1").WillReturnRows(sqlmock.NewRows(tenderColumns).
+			AddRow("tender-1", "Tender", "", model.TenderServiceTypeDelivery, "org-1", "ivanov", model.TenderStatusPublished, nil, nil, nil, nil, false, nil, 4, now, now))
+		mock.ExpectQuery(lockBidQuery).WithArgs("bid-1").WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusPublished, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 2, now, now))
+	}
+	allow := func(*model.Tender, *model.Bid) error { return nil }
+
+	t.Run("rejected", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectQuery(insertQuery).WithArgs("decision-1", "bid-1", "ivanov", model.BidDecisionRejected, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("decision-1"))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusRejected, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 3, now, now))
+		expectInsertEvent(mock, model.EventBidRejected, "bid-1", "tender-1")
+		mock.ExpectCommit()
+
+		bid, tender, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionRejected), allow)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BidStatusRejected, bid.Status)
+		assert.Nil(t, tender)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("approved_without_quorum", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectQuery(insertQuery).WithArgs("decision-1", "bid-1", "ivanov", model.BidDecisionApproved, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("decision-1"))
+		mock.ExpectQuery(quorumQuery).WithArgs("bid-1", "org-1").WillReturnRows(sqlmock.NewRows([]string{"approvals", "responsibles"}).AddRow(1, 3))
+		mock.ExpectCommit()
+
+		bid, tender, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionApproved), allow)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BidStatusPublished, bid.Status)
+		assert.Nil(t, tender)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("approved_with_quorum_closes_tender", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectQuery(insertQuery).WithArgs("decision-1", "bid-1", "ivanov", model.BidDecisionApproved, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("decision-1"))
+		mock.ExpectQuery(quorumQuery).WithArgs("bid-1", "org-1").WillReturnRows(sqlmock.NewRows([]string{"approvals", "responsibles"}).AddRow(1, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO tender_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.TenderStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE tender")).ExpectQuery().WillReturnRows(sqlmock.NewRows(tenderColumns).
+			AddRow("tender-1", "Tender", "", model.TenderServiceTypeDelivery, "org-1", "ivanov", model.TenderStatusClosed, nil, nil, nil, nil, false, nil, 5, now, now))
+		expectInsertEvent(mock, model.EventTenderClosed, "tender-1", "tender-1")
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusApproved, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 3, now, now))
+		expectInsertEvent(mock, model.EventBidApproved, "bid-1", "tender-1")
+		mock.ExpectCommit()
+
+		bid, tender, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionApproved), allow)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BidStatusApproved, bid.Status)
+		if assert.NotNil(t, tender) {
+			assert.Equal(t, model.TenderStatusClosed, tender.Status)
 		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("repeated_decision", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectQuery(insertQuery).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		bid, tender, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionRejected), allow)
+		assert.ErrorIs(t, err, model.ErrDecisionExists)
+		assert.Nil(t, bid)
+		assert.Nil(t, tender)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("check_failed", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectRollback()
+
+		deny := func(*model.Tender, *model.Bid) error { return model.ErrInvalidTransition }
+		bid, _, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionApproved), deny)
+		assert.ErrorIs(t, err, model.ErrInvalidTransition)
+		assert.Nil(t, bid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("bid_not_found", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockTenderQuery).WithArgs("bid-1").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		bid, _, err := repo.SubmitBidDecision(context.Background(), newDecision(model.BidDecisionApproved), allow)
+		assert.ErrorIs(t, err, model.ErrBidNotFound)
+		assert.Nil(t, bid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBidDecisions(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, bid_id, username, decision, created_at
+		FROM bid_decision
+		WHERE bid_id = $1
+		ORDER BY created_at
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		bidID := uuid.New().String()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(bidID).WillReturnRows(sqlmock.NewRows([]string{
+			"id", "bid_id", "username", "decision", "created_at",
+		}).
+			AddRow(uuid.New().String(), bidID, "ivanov", "Approved", time.Now()).
+			AddRow(uuid.New().String(), bidID, "petrov", "Rejected", time.Now()))
+
+		decisions, err := repo.GetBidDecisions(ctx, bidID)
+		assert.NoError(t, err)
+		assert.Len(t, decisions, 2)
+		assert.Equal(t, model.BidDecisionApproved, decisions[0].Decision)
+		assert.Equal(t, model.BidDecisionRejected, decisions[1].Decision)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		bidID := uuid.New().String()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(bidID).WillReturnError(sql.ErrConnDone)
+
+		decisions, err := repo.GetBidDecisions(ctx, bidID)
+		assert.Error(t, err)
+		assert.Nil(t, decisions)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBidVersions(t *testing.T) {
+	getBidVersionsQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, status::text, tender_id, author_type::text, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
+		WHERE id = $1
+		UNION ALL
+		SELECT bid_id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid_history
+		WHERE bid_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		bidID := uuid.New().String()
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
+		}).
+			AddRow(bidID, "Bid v2", "Description", "Published", "tender-id", "User", "author-id", "user1", nil, nil, 2, time.Now(), time.Now()).
+			AddRow(bidID, "Bid v1", "Description", "Created", "tender-id", "User", "author-id", "user1", nil, nil, 1, time.Now(), time.Now())
+
+		mock.ExpectPrepare(getBidVersionsQuery).ExpectQuery().WithArgs(bidID, 5, 0).WillReturnRows(rows)
+
+		bids, err := repo.GetBidVersions(ctx, bidID, 5, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, bids, 2)
+		assert.Equal(t, 2, bids[0].Version)
+		assert.Equal(t, "Bid v1", bids[1].Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		bidID := uuid.New().String()
+		ctx := context.Background()
+
+		mock.ExpectPrepare(getBidVersionsQuery).ExpectQuery().WithArgs(bidID, 5, 0).WillReturnError(sql.ErrConnDone)
+
+		bids, err := repo.GetBidVersions(ctx, bidID, 5, 0)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Nil(t, bids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBidVersion(t *testing.T) {
+	getBidVersionQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, status::text, tender_id, author_type::text, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid
+		WHERE id = $1 AND version = $2
+		UNION ALL
+		SELECT bid_id, name, description, status, tender_id, author_type, author_id, creator_username, price, currency, version, created_at, updated_at
+		FROM bid_history
+		WHERE bid_id = $1 AND version = $2
+		LIMIT 1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		bidID := uuid.New().String()
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at",
+		}).AddRow(bidID, "Bid v1", "Description", "Created", "tender-id", "User", "author-id", "user1", nil, nil, 1, time.Now(), time.Now())
+
+		mock.ExpectPrepare(getBidVersionQuery).ExpectQuery().WithArgs(bidID, 1).WillReturnRows(rows)
+
+		bid, err := repo.GetBidVersion(ctx, bidID, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bid v1", bid.Name)
+		assert.Equal(t, 1, bid.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		bidID := uuid.New().String()
+		ctx := context.Background()
+
+		mock.ExpectPrepare(getBidVersionQuery).ExpectQuery().WithArgs(bidID, 3).WillReturnError(sql.ErrNoRows)
+
+		bid, err := repo.GetBidVersion(ctx, bidID, 3)
+
+		assert.ErrorIs(t, err, model.ErrVersionNotFound)
+		assert.Nil(t, bid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSearchBids(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, b.author_id, b.creator_username, b.price, b.currency, b.version, b.created_at, b.updated_at,
+			ts_rank(b.search_vector, q.query) AS rank,
+			ts_headline('russian', b.name || ' ' || coalesce(b.description, ''), q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM bid b
+		JOIN tender t ON t.id = b.tender_id
+		CROSS JOIN (SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query) q
+		WHERE b.search_vector @@ q.query
+		AND ($2 = '' OR b.tender_id = $2)
+		AND (
+			b.creator_username = $5
+			OR (b.author_type = 'Organization' AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = b.author_id AND e.username = $5
+			))
+			OR (b.status IN ('Published', 'Approved', 'Rejected') AND EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $5
+			))
+		)
+		ORDER BY rank DESC, b.id
+		LIMIT $3 OFFSET $4
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		tenderID := uuid.New().String()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("delivery", tenderID, 5, 0, "ivanov").WillReturnRows(sqlmock.NewRows([]string{
+			"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at", "rank", "snippet",
+		}).
+			AddRow(uuid.New().String(), "Fast delivery", "Description", model.BidStatusPublished, tenderID, model.BidAuthorTypeUser, "petrov", "petrov", nil, nil, 1, time.Now(), time.Now(), 0.4, "Fast <b>delivery</b>"))
+
+		results, err := repo.SearchBids(ctx, "delivery", tenderID, 5, 0, "ivanov")
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Fast <b>delivery</b>", results[0].Snippet)
+		assert.Equal(t, 0.4, results[0].Rank)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("delivery", "", 5, 0, "ivanov").WillReturnError(sql.ErrConnDone)
+
+		results, err := repo.SearchBids(ctx, "delivery", "", 5, 0, "ivanov")
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Nil(t, results)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSaveBidScores(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO bid_score (id, bid_id, criterion_id, username, score, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (bid_id, criterion_id, username) DO UPDATE
+		SET score = EXCLUDED.score, created_at = EXCLUDED.created_at
+		RETURNING id, bid_id, criterion_id, username, score, created_at
+	`)
+	now := time.Now()
+	scores := []model.BidScore{
+		{ID: "score-1", BidID: "bid-1", CriterionID: "criterion-1", Username: "ivanov", Score: 7, CreatedAt: now},
+		{ID: "score-2", BidID: "bid-1", CriterionID: "criterion-2", Username: "ivanov", Score: 9, CreatedAt: now},
+	}
+	columns := []string{"id", "bid_id", "criterion_id", "username", "score", "created_at"}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare(query)
+		prep.ExpectQuery().WithArgs("score-1", "bid-1", "criterion-1", "ivanov", 7, now).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("score-1", "bid-1", "criterion-1", "ivanov", 7, now))
+		prep.ExpectQuery().WithArgs("score-2", "bid-1", "criterion-2", "ivanov", 9, now).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("score-2", "bid-1", "criterion-2", "ivanov", 9, now))
+		mock.ExpectCommit()
+
+		saved, err := repo.SaveBidScores(context.Background(), scores)
+		assert.NoError(t, err)
+		assert.Len(t, saved, 2)
+		assert.Equal(t, 9, saved[1].Score)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		saved, err := repo.SaveBidScores(context.Background(), scores)
+		assert.Error(t, err)
+		assert.Nil(t, saved)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBidScores(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, bid_id, criterion_id, username, score, created_at
+		FROM bid_score
+		WHERE bid_id = $1
+		ORDER BY criterion_id, username
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("bid-1").WillReturnRows(sqlmock.NewRows([]string{"id", "bid_id", "criterion_id", "username", "score", "created_at"}).
+			AddRow("score-1", "bid-1", "criterion-1", "ivanov", 7, time.Now()).
+			AddRow("score-2", "bid-1", "criterion-1", "petrov", 5, time.Now()))
+
+		scores, err := repo.GetBidScores(context.Background(), "bid-1")
+		assert.NoError(t, err)
+		assert.Len(t, scores, 2)
+		assert.Equal(t, "petrov", scores[1].Username)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("bid-1").WillReturnError(sql.ErrConnDone)
+
+		scores, err := repo.GetBidScores(context.Background(), "bid-1")
+		assert.Error(t, err)
+		assert.Nil(t, scores)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPlaceAuctionPrice(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(`
+		SELECT auction_ends_at, deadline, budget_currency
+		FROM tender
+		WHERE id = $1 AND auction
+		FOR UPDATE
+	`)
+	lowestQuery := regexp.QuoteMeta(`
+		SELECT MIN(price)
+		FROM bid
+		WHERE tender_id = $1 AND status = 'Published' AND currency = $2
+	`)
+	extendQuery := regexp.QuoteMeta(`
+			UPDATE tender
+			SET auction_ends_at = $2
+			WHERE id = $1
+		`)
+	bidColumns := []string{"id", "name", "description", "status", "tender_id", "author_type", "author_id", "creator_username", "price", "currency", "version", "created_at", "updated_at"}
+	now := time.Now()
+	extension := 5 * time.Minute
+
+	newBid := func(price model.Decimal) *model.Bid {
+		currency := "RUB"
+		return &model.Bid{
+			ID:              "bid-1",
+			Name:            "Bid",
+			Status:          model.BidStatusPublished,
+			TenderID:        "tender-1",
+			AuthorType:      model.BidAuthorTypeUser,
+			AuthorID:        "petrov",
+			CreatorUsername: "petrov",
+			Price:           &price,
+			Currency:        &currency,
+			Version:         2,
+		}
+	}
+
+	t.Run("success_with_extension", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(time.Minute), nil, "RUB"))
+		mock.ExpectQuery(lowestQuery).WithArgs("tender-1", "RUB").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow([]byte("1500.00")))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusPublished, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", []byte("1400.00"), "RUB", 3, now, now))
+		expectInsertEvent(mock, model.EventBidUpdated, "bid-1", "tender-1")
+		// До конца торгов меньше продления - окно сдвигается
+		mock.ExpectExec(extendQuery).WithArgs("tender-1", now.Add(extension)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		bid, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Decimal("1400.00"), *bid.Price)
+		assert.Equal(t, 3, bid.Version)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success_without_extension", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(time.Hour), nil, "RUB"))
+		mock.ExpectQuery(lowestQuery).WithArgs("tender-1", "RUB").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(nil))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusPublished, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", []byte("1400.00"), "RUB", 3, now, now))
+		expectInsertEvent(mock, model.EventBidUpdated, "bid-1", "tender-1")
+		mock.ExpectCommit()
+
+		_, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("extension_capped_by_deadline", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		deadline := now.Add(2 * time.Minute)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(time.Minute), deadline, "RUB"))
+		mock.ExpectQuery(lowestQuery).WithArgs("tender-1", "RUB").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(nil))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusPublished, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", []byte("1400.00"), "RUB", 3, now, now))
+		expectInsertEvent(mock, model.EventBidUpdated, "bid-1", "tender-1")
+		// Продление не выходит за дедлайн тендера
+		mock.ExpectExec(extendQuery).WithArgs("tender-1", deadline).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no_extension_at_deadline", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		endsAt := now.Add(time.Minute)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(endsAt, endsAt, "RUB"))
+		mock.ExpectQuery(lowestQuery).WithArgs("tender-1", "RUB").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(nil))
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO bid_history")).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.BidStatusPublished))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE bid")).ExpectQuery().WillReturnRows(sqlmock.NewRows(bidColumns).
+			AddRow("bid-1", "Bid", "", model.BidStatusPublished, "tender-1", model.BidAuthorTypeUser, "petrov", "petrov", []byte("1400.00"), "RUB", 3, now, now))
+		expectInsertEvent(mock, model.EventBidUpdated, "bid-1", "tender-1")
+		mock.ExpectCommit()
+
+		_, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("price_not_lower", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(time.Hour), nil, "RUB"))
+		mock.ExpectQuery(lowestQuery).WithArgs("tender-1", "RUB").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow([]byte("1400.00")))
+		mock.ExpectRollback()
+
+		bid, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.ErrorIs(t, err, model.ErrAuctionPriceTooHigh)
+		assert.Nil(t, bid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("mixed_currency", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		// Ставка в USD на редукционе в RUB не сравнивается с минимумом в рублях
+		bid := newBid("10.00")
+		usd := "USD"
+		bid.Currency = &usd
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(time.Hour), nil, "RUB"))
+		mock.ExpectRollback()
+
+		updatedBid, err := repo.PlaceAuctionPrice(context.Background(), bid, now, extension)
+		assert.ErrorIs(t, err, model.ErrCurrencyMismatch)
+		assert.Nil(t, updatedBid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("auction_closed", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnRows(sqlmock.NewRows([]string{"auction_ends_at", "deadline", "budget_currency"}).AddRow(now.Add(-time.Second), nil, "RUB"))
+		mock.ExpectRollback()
+
+		bid, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.ErrorIs(t, err, model.ErrAuctionClosed)
+		assert.Nil(t, bid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not_auction", func(t *testing.T) {
+		db, mock, repo := setupTestBid(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("tender-1").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		bid, err := repo.PlaceAuctionPrice(context.Background(), newBid("1400.00"), now, extension)
+		assert.ErrorIs(t, err, model.ErrNotAuction)
+		assert.Nil(t, bid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -0,0 +1,14 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"database/sql"
+)
+
+// Без курсора оба аргумента NULL и условие keyset не применяется
+func cursorArgs(cursor *model.Cursor) (sql.NullString, sql.NullString) {
+	if cursor == nil {
+		return sql.NullString{}, sql.NullString{}
+	}
+	return sql.NullString{String: cursor.Value, Valid: true}, sql.NullString{String: cursor.ID, Valid: true}
+}
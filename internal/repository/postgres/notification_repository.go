@@ -0,0 +1,310 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type notificationRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewNotificationRepository(db *sql.DB, logger *slog.Logger) repository.NotificationRepository {
+	return &notificationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func scanNotification(row rowScanner, n *model.Notification) error {
+	var readAt sql.NullTime
+	var bidID sql.NullString
+	if err := row.Scan(&n.ID, &n.Username, &n.Type, &n.TenderID, &bidID, &n.Message, &readAt, &n.CreatedAt); err != nil {
+		return err
+	}
+	n.BidID = nil
+	if bidID.Valid {
+		n.BidID = &bidID.String
+	}
+	n.ReadAt = nil
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+	return nil
+}
+
+// CreateNotifications пишет уведомления одной транзакцией, чтобы получатели не увидели событие частично
+func (r *notificationRepository) CreateNotifications(ctx context.Context, notifications []model.Notification) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO notification (id, username, type, tender_id, bid_id, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for creating notification: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, n := range notifications {
+		if _, err := stmt.ExecContext(ctx, n.ID, n.Username, n.Type, n.TenderID, n.BidID, n.Message, n.CreatedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Error creating notification", slog.Any("error", err))
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+		if n.Email == nil {
+			continue
+		}
+		// Письмо ставится в очередь той же транзакцией и отправляется фоновым EmailDispatcher
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_email (notification_id, feedback, status, attempts, next_attempt_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, n.ID, n.Email.Feedback, n.Email.Status, n.Email.Attempts, n.Email.NextAttemptAt); err != nil {
+			r.logger.ErrorContext(ctx, "Error queueing notification email", slog.Any("error", err))
+			return fmt.Errorf("failed to queue notification email: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ClaimNotificationEmails берёт письма, срок отправки которых наступил, и продлевает им срок до leaseUntil,
+// чтобы другой экземпляр не отправил их одновременно
+func (r *notificationRepository) ClaimNotificationEmails(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.NotificationEmailDispatch, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE notification_email e
+		SET next_attempt_at = $2
+		FROM notification n
+		JOIN bid b ON b.id = n.bid_id
+		WHERE n.id = e.notification_id AND e.notification_id IN (
+			SELECT notification_id
+			FROM notification_email
+			WHERE status = 'Pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING e.notification_id, e.feedback, e.status, e.attempts, e.next_attempt_at, e.last_error, e.sent_at, n.username, n.type, n.tender_id, b.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for claiming notification emails: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, now, leaseUntil, limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error claiming notification emails", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to claim notification emails: %w", err)
+	}
+	defer rows.Close()
+
+	var dispatches []model.NotificationEmailDispatch
+	for rows.Next() {
+		dispatch := model.NotificationEmailDispatch{}
+		var lastError sql.NullString
+		var sentAt sql.NullTime
+		if err := rows.Scan(
+			&dispatch.NotificationID,
+			&dispatch.Feedback,
+			&dispatch.Status,
+			&dispatch.Attempts,
+			&dispatch.NextAttemptAt,
+			&lastError,
+			&sentAt,
+			&dispatch.Username,
+			&dispatch.Type,
+			&dispatch.TenderID,
+			&dispatch.BidName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification email: %w", err)
+		}
+		if lastError.Valid {
+			dispatch.LastError = &lastError.String
+		}
+		if sentAt.Valid {
+			dispatch.SentAt = &sentAt.Time
+		}
+		dispatches = append(dispatches, dispatch)
+	}
+
+	return dispatches, nil
+}
+
+func (r *notificationRepository) UpdateNotificationEmail(ctx context.Context, email *model.NotificationEmail) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE notification_email
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, sent_at = $6
+		WHERE notification_id = $1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for updating notification email: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		email.NotificationID,
+		email.Status,
+		email.Attempts,
+		email.NextAttemptAt,
+		email.LastError,
+		email.SentAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating notification email", slog.Any("error", err))
+		return fmt.Errorf("failed to update notification email: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) GetNotifications(ctx context.Context, username string, unreadOnly bool, limit int, offset int) ([]model.Notification, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, username, type, tender_id, bid_id, message, read_at, created_at
+		FROM notification
+		WHERE username = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting notifications: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, username, unreadOnly, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting notifications", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []model.Notification
+	for rows.Next() {
+		notification := model.Notification{}
+		if err := scanNotification(rows, &notification); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnreadNotifications(ctx context.Context, username string) (int, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT COUNT(*) FROM notification WHERE username = $1 AND read_at IS NULL
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement for counting unread notifications: %w", err)
+	}
+	defer stmt.Close()
+
+	var count int
+	if err := stmt.QueryRowContext(ctx, username).Scan(&count); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting unread notifications", slog.Any("error", err))
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationRead отмечает уведомление получателя прочитанным; время первого прочтения сохраняется
+func (r *notificationRepository) MarkNotificationRead(ctx context.Context, id string, username string, now time.Time) (*model.Notification, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE notification SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND username = $2
+		RETURNING id, username, type, tender_id, bid_id, message, read_at, created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for marking notification read: %w", err)
+	}
+	defer stmt.Close()
+
+	notification := &model.Notification{}
+	if err := scanNotification(stmt.QueryRowContext(ctx, id, username, now), notification); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrNotificationNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error marking notification read", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return notification, nil
+}
+
+func (r *notificationRepository) MarkAllNotificationsRead(ctx context.Context, username string, now time.Time) (int, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE notification SET read_at = $2 WHERE username = $1 AND read_at IS NULL
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement for marking notifications read: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, username, now)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error marking notifications read", slog.Any("error", err))
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	marked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(marked), nil
+}
+
+// GetTenderBidAuthors возвращает авторов предложений, которые видела организация тендера
+func (r *notificationRepository) GetTenderBidAuthors(ctx context.Context, tenderID string) ([]string, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT DISTINCT creator_username
+		FROM bid
+		WHERE tender_id = $1 AND status IN ('Published', 'Approved', 'Rejected')
+		ORDER BY creator_username
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting tender bid authors: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, tenderID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting tender bid authors", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting tender bid authors: %w", err)
+	}
+	defer rows.Close()
+
+	var usernames []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, fmt.Errorf("failed to scan bid author: %w", err)
+		}
+		usernames = append(usernames, username)
+	}
+
+	return usernames, nil
+}
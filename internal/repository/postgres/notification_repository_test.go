@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestNotification(t *testing.T) (*sql.DB, sqlmock.Sqlmock, repository.NotificationRepository) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	logger := slog.Default()
+	repo := NewNotificationRepository(db, logger)
+	return db, mock, repo
+}
+
+var notificationColumns = []string{"id", "username", "type", "tender_id", "bid_id", "message", "read_at", "created_at"}
+
+func TestCreateNotifications(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO notification (id, username, type, tender_id, bid_id, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`)
+	now := time.Now()
+	bidID := "bid-1"
+	notifications := []model.Notification{
+		{ID: "notification-1", Username: "ivanov", Type: model.NotificationBidApproved, TenderID: "tender-1", BidID: &bidID, Message: "approved", CreatedAt: now},
+		{ID: "notification-2", Username: "petrov", Type: model.NotificationTenderClosed, TenderID: "tender-1", Message: "closed", CreatedAt: now},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		prepare := mock.ExpectPrepare(query)
+		prepare.ExpectExec().WithArgs("notification-1", "ivanov", model.NotificationBidApproved, "tender-1", &bidID, "approved", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		prepare.ExpectExec().WithArgs("notification-2", "petrov", model.NotificationTenderClosed, "tender-1", nil, "closed", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.CreateNotifications(context.Background(), notifications))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error rolls back", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		assert.Error(t, repo.CreateNotifications(context.Background(), notifications))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("queues email in the same transaction", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		withEmail := []model.Notification{notifications[0]}
+		withEmail[0].Email = &model.NotificationEmail{Feedback: "", Status: model.NotificationEmailPending, NextAttemptAt: now}
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("notification-1", "ivanov", model.NotificationBidApproved, "tender-1", &bidID, "approved", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notification_email (notification_id, feedback, status, attempts, next_attempt_at)`)).
+			WithArgs("notification-1", "", model.NotificationEmailPending, 0, now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.CreateNotifications(context.Background(), withEmail))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestClaimNotificationEmails(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE notification_email e
+		SET next_attempt_at = $2
+		FROM notification n
+		JOIN bid b ON b.id = n.bid_id
+		WHERE n.id = e.notification_id AND e.notification_id IN (
+			SELECT notification_id
+			FROM notification_email
+			WHERE status = 'Pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING e.notification_id, e.feedback, e.status, e.attempts, e.next_attempt_at, e.last_error, e.sent_at, n.username, n.type, n.tender_id, b.name
+	`)
+
+	db, mock, repo := setupTestNotification(t)
+	defer db.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs(now, leaseUntil, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"notification_id", "feedback", "status", "attempts", "next_attempt_at", "last_error", "sent_at", "username", "type", "tender_id", "name"}).
+			AddRow("notification-1", "Уточните сроки", "Pending", 1, leaseUntil, "connection refused", nil, "ivanov", "BidFeedback", "tender-1", "Поставка труб"))
+
+	dispatches, err := repo.ClaimNotificationEmails(context.Background(), now, leaseUntil, 20)
+	assert.NoError(t, err)
+	require.Len(t, dispatches, 1)
+	assert.Equal(t, "notification-1", dispatches[0].NotificationID)
+	assert.Equal(t, "Уточните сроки", dispatches[0].Feedback)
+	assert.Equal(t, 1, dispatches[0].Attempts)
+	assert.Equal(t, "connection refused", *dispatches[0].LastError)
+	assert.Nil(t, dispatches[0].SentAt)
+	assert.Equal(t, "ivanov", dispatches[0].Username)
+	assert.Equal(t, model.NotificationBidFeedback, dispatches[0].Type)
+	assert.Equal(t, "Поставка труб", dispatches[0].BidName)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNotificationEmail(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE notification_email
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, sent_at = $6
+		WHERE notification_id = $1
+	`)
+	now := time.Now()
+	email := &model.NotificationEmail{
+		NotificationID: "notification-1",
+		Status:         model.NotificationEmailSent,
+		Attempts:       2,
+		NextAttemptAt:  now,
+		SentAt:         &now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs("notification-1", model.NotificationEmailSent, 2, now, nil, &now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.UpdateNotificationEmail(context.Background(), email))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.UpdateNotificationEmail(context.Background(), email), model.ErrNotificationNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetNotifications(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, username, type, tender_id, bid_id, message, read_at, created_at
+		FROM notification
+		WHERE username = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`)
+	now := time.Now()
+
+	db, mock, repo := setupTestNotification(t)
+	defer db.Close()
+
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("ivanov", true, 10, 0).
+		WillReturnRows(sqlmock.NewRows(notificationColumns).
+			AddRow("notification-1", "ivanov", "BidRejected", "tender-1", "bid-1", "rejected", nil, now).
+			AddRow("notification-2", "ivanov", "TenderClosed", "tender-1", nil, "closed", nil, now))
+
+	notifications, err := repo.GetNotifications(context.Background(), "ivanov", true, 10, 0)
+	assert.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, model.NotificationBidRejected, notifications[0].Type)
+	assert.Equal(t, "bid-1", *notifications[0].BidID)
+	assert.Nil(t, notifications[1].BidID)
+	assert.Nil(t, notifications[1].ReadAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountUnreadNotifications(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT COUNT(*) FROM notification WHERE username = $1 AND read_at IS NULL
+	`)
+
+	db, mock, repo := setupTestNotification(t)
+	defer db.Close()
+
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("ivanov").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountUnreadNotifications(context.Background(), "ivanov")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE notification SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND username = $2
+		RETURNING id, username, type, tender_id, bid_id, message, read_at, created_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("notification-1", "ivanov", now).
+			WillReturnRows(sqlmock.NewRows(notificationColumns).
+				AddRow("notification-1", "ivanov", "BidFeedback", "tender-1", "bid-1", "feedback", now, now))
+
+		notification, err := repo.MarkNotificationRead(context.Background(), "notification-1", "ivanov", now)
+		assert.NoError(t, err)
+		require.NotNil(t, notification.ReadAt)
+		assert.Equal(t, now, *notification.ReadAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestNotification(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("notification-1", "petrov", now).
+			WillReturnRows(sqlmock.NewRows(notificationColumns))
+
+		notification, err := repo.MarkNotificationRead(context.Background(), "notification-1", "petrov", now)
+		assert.ErrorIs(t, err, model.ErrNotificationNotFound)
+		assert.Nil(t, notification)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE notification SET read_at = $2 WHERE username = $1 AND read_at IS NULL
+	`)
+	now := time.Now()
+
+	db, mock, repo := setupTestNotification(t)
+	defer db.Close()
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("ivanov", now).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	marked, err := repo.MarkAllNotificationsRead(context.Background(), "ivanov", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTenderBidAuthors(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT DISTINCT creator_username
+		FROM bid
+		WHERE tender_id = $1 AND status IN ('Published', 'Approved', 'Rejected')
+		ORDER BY creator_username
+	`)
+
+	db, mock, repo := setupTestNotification(t)
+	defer db.Close()
+
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1").
+		WillReturnRows(sqlmock.NewRows([]string{"creator_username"}).AddRow("ivanov").AddRow("petrov"))
+
+	authors, err := repo.GetTenderBidAuthors(context.Background(), "tender-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ivanov", "petrov"}, authors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
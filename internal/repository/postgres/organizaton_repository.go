@@ -5,8 +5,12 @@ import (
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type organizationRepository struct {
@@ -75,3 +79,224 @@ func (r *organizationRepository) IsUserResponsibleForOrganization(ctx context.Co
 
 	return exists, nil
 }
+
+func (r *organizationRepository) CreateOrganization(ctx context.Context, organization *model.Organization, responsibleUserID string) (*model.Organization, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO organization (id, name, description, type)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, description, type, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for creating organization: %w", err)
+	}
+	defer stmt.Close()
+
+	var createdOrganization model.Organization
+	err = stmt.QueryRowContext(ctx, organization.Id, organization.Name, organization.Description, organization.Type).Scan(
+		&createdOrganization.Id,
+		&createdOrganization.Name,
+		&createdOrganization.Description,
+		&createdOrganization.Type,
+		&createdOrganization.Created_at,
+		&createdOrganization.Updated_at,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating organization", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for creating organization: %w", err)
+	}
+
+	responsibleStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO organization_responsible (id, organization_id, user_id)
+		VALUES ($1, $2, $3)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for adding organization responsible: %w", err)
+	}
+	defer responsibleStmt.Close()
+
+	_, err = responsibleStmt.ExecContext(ctx, uuid.NewString(), createdOrganization.Id, responsibleUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add organization responsible: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &createdOrganization, nil
+}
+
+func (r *organizationRepository) GetOrganizations(ctx context.Context, limit int, offset int) ([]model.Organization, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, type, created_at, updated_at
+		FROM organization
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting organizations: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting organizations", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var organizations []model.Organization
+	for rows.Next() {
+		organization := model.Organization{}
+		if err := rows.Scan(
+			&organization.Id,
+			&organization.Name,
+			&organization.Description,
+			&organization.Type,
+			&organization.Created_at,
+			&organization.Updated_at,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		organizations = append(organizations, organization)
+	}
+
+	return organizations, nil
+}
+
+func (r *organizationRepository) UpdateOrganization(ctx context.Context, organization *model.Organization) (*model.Organization, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE organization
+		SET name = $2, description = $3, type = $4, updated_at = $5
+		WHERE id = $1
+		RETURNING id, name, description, type, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for updating organization: %w", err)
+	}
+	defer stmt.Close()
+
+	var updatedOrganization model.Organization
+	err = stmt.QueryRowContext(ctx, organization.Id, organization.Name, organization.Description, organization.Type, time.Now()).Scan(
+		&updatedOrganization.Id,
+		&updatedOrganization.Name,
+		&updatedOrganization.Description,
+		&updatedOrganization.Type,
+		&updatedOrganization.Created_at,
+		&updatedOrganization.Updated_at,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrOrganizationNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error updating organization", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for updating organization: %w", err)
+	}
+
+	return &updatedOrganization, nil
+}
+
+func (r *organizationRepository) AddOrganizationResponsible(ctx context.Context, responsible *model.OrganizationResponsible) (*model.OrganizationResponsible, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO organization_responsible (id, organization_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+		RETURNING id, organization_id, user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing statement for adding organization responsible: %w", err)
+	}
+	defer stmt.Close()
+
+	var createdResponsible model.OrganizationResponsible
+	err = stmt.QueryRowContext(ctx, responsible.ID, responsible.OrganizationID, responsible.UserID).Scan(
+		&createdResponsible.ID,
+		&createdResponsible.OrganizationID,
+		&createdResponsible.UserID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrResponsibleExists
+		}
+		return nil, fmt.Errorf("error adding organization responsible: %w", err)
+	}
+
+	return &createdResponsible, nil
+}
+
+// RemoveOrganizationResponsible удаляет ответственного, если после этого у организации останется хотя бы один.
+// Строка организации блокируется, чтобы одновременные удаления не оставили её без ответственных
+func (r *organizationRepository) RemoveOrganizationResponsible(ctx context.Context, organizationID string, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id
+		FROM organization
+		WHERE id = $1
+		FOR UPDATE
+	`, organizationID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrOrganizationNotFound
+		}
+		return fmt.Errorf("error locking organization: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM organization_responsible
+		WHERE organization_id = $1 AND user_id = $2
+	`, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("error removing organization responsible: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrResponsibleNotFound
+	}
+
+	var remaining int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM organization_responsible
+		WHERE organization_id = $1
+	`, organizationID).Scan(&remaining)
+	if err != nil {
+		return fmt.Errorf("error counting organization responsibles: %w", err)
+	}
+	if remaining == 0 {
+		return model.ErrLastResponsible
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"database/sql"
@@ -174,3 +175,285 @@ func TestIsUserResponsibleForOrganization(t *testing.T) {
 		assert.False(t, isResponsible)
 	})
 }
+
+func TestCreateOrganization(t *testing.T) {
+	organizationQuery := regexp.QuoteMeta(`
+		INSERT INTO organization (id, name, description, type)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, description, type, created_at, updated_at
+	`)
+	responsibleQuery := regexp.QuoteMeta(`
+		INSERT INTO organization_responsible (id, organization_id, user_id)
+		VALUES ($1, $2, $3)
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organization := &model.Organization{
+			Id:          uuid.New().String(),
+			Name:        "Test Organization",
+			Description: "Test Description",
+			Type:        model.OrganizationTypeLLC,
+		}
+		userID := uuid.New().String()
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(organizationQuery).ExpectQuery().WithArgs(
+			organization.Id, organization.Name, organization.Description, organization.Type,
+		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "type", "created_at", "updated_at"}).
+			AddRow(organization.Id, organization.Name, organization.Description, organization.Type, time.Now(), time.Now()))
+		mock.ExpectPrepare(responsibleQuery).ExpectExec().WithArgs(sqlmock.AnyArg(), organization.Id, userID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		createdOrganization, err := repo.CreateOrganization(ctx, organization, userID)
+		assert.NoError(t, err)
+		assert.NotNil(t, createdOrganization)
+		assert.Equal(t, organization.Id, createdOrganization.Id)
+		assert.Equal(t, organization.Type, createdOrganization.Type)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("responsible error", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organization := &model.Organization{
+			Id:   uuid.New().String(),
+			Name: "Test Organization",
+			Type: model.OrganizationTypeIE,
+		}
+		userID := uuid.New().String()
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(organizationQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "type", "created_at", "updated_at"}).
+			AddRow(organization.Id, organization.Name, "", organization.Type, time.Now(), time.Now()))
+		mock.ExpectPrepare(responsibleQuery).ExpectExec().WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		createdOrganization, err := repo.CreateOrganization(ctx, organization, userID)
+		assert.Error(t, err)
+		assert.Nil(t, createdOrganization)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetOrganizations(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, name, description, type, created_at, updated_at
+		FROM organization
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(10, 0).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "type", "created_at", "updated_at"}).
+			AddRow("org1_id", "ИП Петров", "Ремонт квартир", "IE", time.Now(), time.Now()).
+			AddRow("org2_id", "ООО Ромашка", "Производство цветов", "LLC", time.Now(), time.Now()))
+
+		organizations, err := repo.GetOrganizations(ctx, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, organizations, 2)
+		assert.Equal(t, model.OrganizationTypeIE, organizations[0].Type)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(10, 0).WillReturnError(sql.ErrConnDone)
+
+		organizations, err := repo.GetOrganizations(ctx, 10, 0)
+		assert.Error(t, err)
+		assert.Nil(t, organizations)
+	})
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE organization
+		SET name = $2, description = $3, type = $4, updated_at = $5
+		WHERE id = $1
+		RETURNING id, name, description, type, created_at, updated_at
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organization := &model.Organization{
+			Id:          uuid.New().String(),
+			Name:        "Updated Organization",
+			Description: "Updated Description",
+			Type:        model.OrganizationTypeJSC,
+		}
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(
+			organization.Id, organization.Name, organization.Description, organization.Type, sqlmock.AnyArg(),
+		).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "type", "created_at", "updated_at"}).
+			AddRow(organization.Id, organization.Name, organization.Description, organization.Type, time.Now(), time.Now()))
+
+		updatedOrganization, err := repo.UpdateOrganization(ctx, organization)
+		assert.NoError(t, err)
+		assert.Equal(t, organization.Name, updatedOrganization.Name)
+		assert.Equal(t, organization.Type, updatedOrganization.Type)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organization := &model.Organization{Id: uuid.New().String()}
+
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrNoRows)
+
+		updatedOrganization, err := repo.UpdateOrganization(ctx, organization)
+		assert.ErrorIs(t, err, model.ErrOrganizationNotFound)
+		assert.Nil(t, updatedOrganization)
+	})
+}
+
+func TestAddOrganizationResponsible(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO organization_responsible (id, organization_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+		RETURNING id, organization_id, user_id
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		responsible := &model.OrganizationResponsible{
+			ID:             uuid.New().String(),
+			OrganizationID: uuid.New().String(),
+			UserID:         uuid.New().String(),
+		}
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(responsible.ID, responsible.OrganizationID, responsible.UserID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id"}).
+				AddRow(responsible.ID, responsible.OrganizationID, responsible.UserID))
+
+		createdResponsible, err := repo.AddOrganizationResponsible(ctx, responsible)
+		assert.NoError(t, err)
+		assert.Equal(t, responsible, createdResponsible)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		responsible := &model.OrganizationResponsible{
+			ID:             uuid.New().String(),
+			OrganizationID: uuid.New().String(),
+			UserID:         uuid.New().String(),
+		}
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(responsible.ID, responsible.OrganizationID, responsible.UserID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "user_id"}))
+
+		createdResponsible, err := repo.AddOrganizationResponsible(ctx, responsible)
+		assert.ErrorIs(t, err, model.ErrResponsibleExists)
+		assert.Nil(t, createdResponsible)
+	})
+}
+
+func TestRemoveOrganizationResponsible(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(`
+		SELECT id
+		FROM organization
+		WHERE id = $1
+		FOR UPDATE
+	`)
+	deleteQuery := regexp.QuoteMeta(`
+		DELETE FROM organization_responsible
+		WHERE organization_id = $1 AND user_id = $2
+	`)
+	countQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM organization_responsible
+		WHERE organization_id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organizationID := uuid.New().String()
+		userID := uuid.New().String()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(organizationID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(organizationID))
+		mock.ExpectExec(deleteQuery).WithArgs(organizationID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(countQuery).WithArgs(organizationID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.RemoveOrganizationResponsible(ctx, organizationID, userID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("last responsible", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organizationID := uuid.New().String()
+		userID := uuid.New().String()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(organizationID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(organizationID))
+		mock.ExpectExec(deleteQuery).WithArgs(organizationID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(countQuery).WithArgs(organizationID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		err := repo.RemoveOrganizationResponsible(ctx, organizationID, userID)
+		assert.ErrorIs(t, err, model.ErrLastResponsible)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestOrganization(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		organizationID := uuid.New().String()
+		userID := uuid.New().String()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(organizationID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(organizationID))
+		mock.ExpectExec(deleteQuery).WithArgs(organizationID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.RemoveOrganizationResponsible(ctx, organizationID, userID)
+		assert.ErrorIs(t, err, model.ErrResponsibleNotFound)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
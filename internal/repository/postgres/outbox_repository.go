@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type outboxRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewOutboxRepository(db *sql.DB, logger *slog.Logger) repository.OutboxRepository {
+	return &outboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// insertEvent пишет событие в outbox в транзакции изменения, чтобы оно не потерялось и не опередило данные
+func insertEvent(ctx context.Context, tx *sql.Tx, event *model.Event) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox_event (type, aggregate_type, aggregate_id, tender_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, event.Type, event.AggregateType, event.AggregateID, event.TenderID, []byte(event.Payload), event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+// Ключ advisory-блокировки, которая выстраивает публикацию outbox всех экземпляров сервиса в очередь
+const outboxPublishLockKey int64 = 7_204_513_001
+
+// PublishPendingEvents блокирует пачку неопубликованных событий и отмечает те, что publish принял.
+// Публикации идут по одной за раз, поэтому publish_seq растёт в порядке их фиксации
+func (r *outboxRepository) PublishPendingEvents(ctx context.Context, limit int, publish func(context.Context, model.Event) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, outboxPublishLockKey); err != nil {
+		return 0, fmt.Errorf("failed to lock outbox publishing: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, type, aggregate_type, aggregate_id, tender_id, payload, created_at
+		FROM outbox_event
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []model.Event
+	for rows.Next() {
+		var event model.Event
+		if err := rows.Scan(&event.ID, &event.Type, &event.AggregateType, &event.AggregateID, &event.TenderID, &event.Payload, &event.CreatedAt); err != nil {
+			return 0, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+	rows.Close()
+
+	// Публикация останавливается на первой ошибке, чтобы не нарушить порядок событий
+	var published []int64
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) > 0 {
+		// Номера публикации продолжают последний выданный в порядке id внутри пачки
+		_, err = tx.ExecContext(ctx, `
+			UPDATE outbox_event e
+			SET published_at = $2, publish_seq = m.last_seq + p.ord
+			FROM unnest($1::bigint[]) WITH ORDINALITY AS p(id, ord),
+				(SELECT COALESCE(MAX(publish_seq), 0) AS last_seq FROM outbox_event) m
+			WHERE e.id = p.id
+		`, pq.Array(published), time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark outbox events published: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if publishErr != nil {
+		return len(published), fmt.Errorf("failed to publish event: %w", publishErr)
+	}
+
+	return len(published), nil
+}
+
+// GetTenderEvents читает опубликованные события предложений тендера после номера публикации afterSeq.
+// Неопубликованные события пропускаются: их номер ещё не выдан и окажется больше уже прочитанных
+func (r *outboxRepository) GetTenderEvents(ctx context.Context, tenderID string, afterSeq int64, limit int) ([]model.Event, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, publish_seq, type, aggregate_type, aggregate_id, tender_id, payload, created_at
+		FROM outbox_event
+		WHERE tender_id = $1 AND aggregate_type = 'Bid' AND publish_seq > $2
+		ORDER BY publish_seq
+		LIMIT $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting tender events: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, tenderID, afterSeq, limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting tender events", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting tender events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []model.Event
+	for rows.Next() {
+		var event model.Event
+		if err := rows.Scan(&event.ID, &event.Sequence, &event.Type, &event.AggregateType, &event.AggregateID, &event.TenderID, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tender event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestOutbox(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *outboxRepository) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := &outboxRepository{db: db, logger: logger}
+	return db, mock, repo
+}
+
+// expectInsertEvent ожидает запись события в outbox внутри транзакции изменения
+func expectInsertEvent(mock sqlmock.Sqlmock, eventType model.EventType, aggregateID string, tenderID string) {
+	mock.ExpectExec(regexp.QuoteMeta(`
+		INSERT INTO outbox_event (type, aggregate_type, aggregate_id, tender_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`)).WithArgs(eventType, sqlmock.AnyArg(), aggregateID, tenderID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestPublishPendingEvents(t *testing.T) {
+	selectQuery := regexp.QuoteMeta(`
+		SELECT id, type, aggregate_type, aggregate_id, tender_id, payload, created_at
+		FROM outbox_event
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`)
+	lockQuery := regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)
+	updateQuery := regexp.QuoteMeta(`
+			UPDATE outbox_event e
+			SET published_at = $2, publish_seq = m.last_seq + p.ord
+			FROM unnest($1::bigint[]) WITH ORDINALITY AS p(id, ord),
+				(SELECT COALESCE(MAX(publish_seq), 0) AS last_seq FROM outbox_event) m
+			WHERE e.id = p.id
+		`)
+	columns := []string{"id", "type", "aggregate_type", "aggregate_id", "tender_id", "payload", "created_at"}
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lockQuery).WithArgs(outboxPublishLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs(10).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(1), "TenderCreated", "Tender", "tender-1", "tender-1", []byte(`{"id":"tender-1"}`), now).
+			AddRow(int64(2), "BidSubmitted", "Bid", "bid-1", "tender-1", []byte(`{"id":"bid-1"}`), now))
+		mock.ExpectExec(updateQuery).WithArgs(pq.Array([]int64{1, 2}), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		var published []model.Event
+		count, err := repo.PublishPendingEvents(context.Background(), 10, func(ctx context.Context, event model.Event) error {
+			published = append(published, event)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Len(t, published, 2)
+		assert.Equal(t, model.EventTenderCreated, published[0].Type)
+		assert.Equal(t, model.EventBidSubmitted, published[1].Type)
+		assert.Equal(t, "tender-1", published[1].TenderID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stops at first publish error", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lockQuery).WithArgs(outboxPublishLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs(10).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(1), "TenderCreated", "Tender", "tender-1", "tender-1", []byte(`{}`), now).
+			AddRow(int64(2), "TenderPublished", "Tender", "tender-1", "tender-1", []byte(`{}`), now).
+			AddRow(int64(3), "TenderClosed", "Tender", "tender-1", "tender-1", []byte(`{}`), now))
+		mock.ExpectExec(updateQuery).WithArgs(pq.Array([]int64{1}), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		count, err := repo.PublishPendingEvents(context.Background(), 10, func(ctx context.Context, event model.Event) error {
+			if event.ID == 2 {
+				return errors.New("publisher unavailable")
+			}
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lockQuery).WithArgs(outboxPublishLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs(10).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectCommit()
+
+		count, err := repo.PublishPendingEvents(context.Background(), 10, func(ctx context.Context, event model.Event) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lockQuery).WithArgs(outboxPublishLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs(10).WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		_, err := repo.PublishPendingEvents(context.Background(), 10, func(ctx context.Context, event model.Event) error {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTenderEvents(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, publish_seq, type, aggregate_type, aggregate_id, tender_id, payload, created_at
+		FROM outbox_event
+		WHERE tender_id = $1 AND aggregate_type = 'Bid' AND publish_seq > $2
+		ORDER BY publish_seq
+		LIMIT $3
+	`)
+	columns := []string{"id", "publish_seq", "type", "aggregate_type", "aggregate_id", "tender_id", "payload", "created_at"}
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1", int64(5), 100).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(9), int64(6), "BidSubmitted", "Bid", "bid-1", "tender-1", []byte(`{"id":"bid-1","status":"Published"}`), now).
+			AddRow(int64(8), int64(7), "BidApproved", "Bid", "bid-1", "tender-1", []byte(`{"id":"bid-1","status":"Approved"}`), now))
+
+		events, err := repo.GetTenderEvents(context.Background(), "tender-1", 5, 100)
+		assert.NoError(t, err)
+		require.Len(t, events, 2)
+		// Событие с меньшим id зафиксировано позже и идёт по номеру публикации
+		assert.Equal(t, int64(9), events[0].ID)
+		assert.Equal(t, int64(6), events[0].Sequence)
+		assert.Equal(t, int64(7), events[1].Sequence)
+		assert.Equal(t, model.EventBidApproved, events[1].Type)
+		assert.Equal(t, "bid-1", events[1].AggregateID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestOutbox(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1", int64(0), 100).WillReturnError(errors.New("db error"))
+
+		events, err := repo.GetTenderEvents(context.Background(), "tender-1", 0, 100)
+		assert.Error(t, err)
+		assert.Nil(t, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
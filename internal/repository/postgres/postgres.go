@@ -6,6 +6,9 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// Код ошибки PostgreSQL unique_violation
+const uniqueViolationCode = "23505"
+
 func NewDB(strConn string) (*sql.DB, error) {
 	db, err := sql.Open("postgres", strConn)
 	if err != nil {
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Общие условия фильтрации тендеров, занимают параметры $1-$8
+const tenderFilterCondition = `(cardinality($1::text[]) = 0 OR t.service_type::text = ANY($1))
+		AND (cardinality($2::text[]) = 0 OR t.status::text = ANY($2))
+		AND ($3::varchar IS NULL OR t.organization_id = $3)
+		AND ($4::varchar IS NULL OR t.creator_username = $4)
+		AND ($5::timestamptz IS NULL OR t.created_at >= $5)
+		AND ($6::timestamptz IS NULL OR t.created_at <= $6)
+		AND ($7::timestamptz IS NULL OR t.updated_at >= $7)
+		AND ($8::timestamptz IS NULL OR t.updated_at <= $8)`
+
+type tenderSortColumn struct {
+	name     string
+	castType string
+}
+
+// Сортировка только по известным колонкам, в запрос они подставляются как есть
+var tenderSortColumns = map[model.TenderSortField]tenderSortColumn{
+	model.TenderSortName:      {name: "t.name", castType: "varchar"},
+	model.TenderSortCreatedAt: {name: "t.created_at", castType: "timestamptz"},
+	model.TenderSortUpdatedAt: {name: "t.updated_at", castType: "timestamptz"},
+}
+
+func tenderFilterArgs(filter model.TenderFilter) []any {
+	serviceTypes := make([]string, len(filter.ServiceTypes))
+	for i, st := range filter.ServiceTypes {
+		serviceTypes[i] = string(st)
+	}
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+
+	return []any{
+		pq.Array(serviceTypes),
+		pq.Array(statuses),
+		nullString(filter.OrganizationID),
+		nullString(filter.CreatorUsername),
+		filter.CreatedFrom,
+		filter.CreatedTo,
+		filter.UpdatedFrom,
+		filter.UpdatedTo,
+	}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
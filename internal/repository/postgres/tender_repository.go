@@ -40,9 +40,9 @@ func (r *tenderRepository) CreateTender(ctx context.Context, tender *model.Tende
 	}()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO tender (id, name, description, service_type, organization_id, creator_username, status, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		INSERT INTO tender (id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement for creating tender: %w", err)
@@ -57,6 +57,12 @@ func (r *tenderRepository) CreateTender(ctx context.Context, tender *model.Tende
 		tender.OrganizationID,
 		tender.CreatorUsername,
 		tender.Status,
+		tender.BudgetMin,
+		tender.BudgetMax,
+		tender.BudgetCurrency,
+		tender.Deadline,
+		tender.Auction,
+		tender.AuctionEndsAt,
 		tender.Version,
 	)
 
@@ -68,6 +74,12 @@ func (r *tenderRepository) CreateTender(ctx context.Context, tender *model.Tende
 		&tender.OrganizationID,
 		&tender.CreatorUsername,
 		&tender.Status,
+		&tender.BudgetMin,
+		&tender.BudgetMax,
+		&tender.BudgetCurrency,
+		&tender.Deadline,
+		&tender.Auction,
+		&tender.AuctionEndsAt,
 		&tender.Version,
 		&tender.CreatedAt,
 		&tender.UpdatedAt,
@@ -76,6 +88,14 @@ func (r *tenderRepository) CreateTender(ctx context.Context, tender *model.Tende
 		return nil, fmt.Errorf("failed to execute query and scan result: %w", err)
 	}
 
+	event, err := model.NewTenderEvent("", tender)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -83,25 +103,43 @@ func (r *tenderRepository) CreateTender(ctx context.Context, tender *model.Tende
 	return tender, nil
 }
 
-func (r *tenderRepository) GetTenders(ctx context.Context, limit int, offset int, serviceTypes []model.TenderServiceType) ([]model.Tender, error) {
+func (r *tenderRepository) GetTenders(ctx context.Context, limit int, offset int, cursor *model.Cursor, filter model.TenderFilter, username string) ([]model.Tender, error) {
 
-	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-		FROM tender
-		WHERE service_type = ANY($1)
-		LIMIT $2 OFFSET $3
-	`)
+	sortColumn, ok := tenderSortColumns[filter.Sort]
+	if !ok {
+		sortColumn = tenderSortColumns[model.TenderSortName]
+	}
+	order, comparison := "ASC", ">"
+	if filter.Desc {
+		order, comparison = "DESC", "<"
+	}
+
+	// Неопубликованные и закрытые тендеры видны только ответственным за организацию
+	stmt, err := r.db.PrepareContext(ctx, fmt.Sprintf(`
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at
+		FROM tender t
+		WHERE %[1]s
+		AND (
+			t.status = 'Published'
+			OR EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $9
+			)
+		)
+		AND ($10::text IS NULL OR (%[2]s, t.id) %[3]s ($10::%[4]s, $11))
+		ORDER BY %[2]s %[5]s, t.id %[5]s
+		LIMIT $12 OFFSET $13
+	`, tenderFilterCondition, sortColumn.name, comparison, sortColumn.castType, order))
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement for getting tenders: %w", err)
 	}
 	defer stmt.Close()
 
-	serviceTypeStrings := make([]string, len(serviceTypes))
-	for i, st := range serviceTypes {
-		serviceTypeStrings[i] = string(st)
-	}
-
-	rows, err := stmt.QueryContext(ctx, pq.Array(serviceTypeStrings), limit, offset)
+	cursorValue, cursorID := cursorArgs(cursor)
+	args := append(tenderFilterArgs(filter), username, cursorValue, cursorID, limit, offset)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to execute query for getting tenders: %w", err)
@@ -120,6 +158,12 @@ func (r *tenderRepository) GetTenders(ctx context.Context, limit int, offset int
 			&tender.OrganizationID,
 			&tender.CreatorUsername,
 			&tender.Status,
+			&tender.BudgetMin,
+			&tender.BudgetMax,
+			&tender.BudgetCurrency,
+			&tender.Deadline,
+			&tender.Auction,
+			&tender.AuctionEndsAt,
 			&tender.Version,
 			&tender.CreatedAt,
 			&tender.UpdatedAt,
@@ -136,7 +180,7 @@ func (r *tenderRepository) GetTenders(ctx context.Context, limit int, offset int
 func (r *tenderRepository) GetTenderById(ctx context.Context, id string) (*model.Tender, error) {
 
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender
 		WHERE id = $1
 	`)
@@ -154,6 +198,12 @@ func (r *tenderRepository) GetTenderById(ctx context.Context, id string) (*model
 		&tender.OrganizationID,
 		&tender.CreatorUsername,
 		&tender.Status,
+		&tender.BudgetMin,
+		&tender.BudgetMax,
+		&tender.BudgetCurrency,
+		&tender.Deadline,
+		&tender.Auction,
+		&tender.AuctionEndsAt,
 		&tender.Version,
 		&tender.CreatedAt,
 		&tender.UpdatedAt,
@@ -169,12 +219,64 @@ func (r *tenderRepository) GetTenderById(ctx context.Context, id string) (*model
 	return &tender, nil
 }
 
-func (r *tenderRepository) GetTenderByUsername(ctx context.Context, limit int, offset int, username string) ([]model.Tender, error) {
+func (r *tenderRepository) GetExpiredTenders(ctx context.Context, now time.Time, limit int) ([]model.Tender, error) {
 
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE status = 'Published' AND deadline <= $1
+		ORDER BY deadline, id
+		LIMIT $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting expired tenders: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, now, limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting expired tenders", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting expired tenders: %w", err)
+	}
+	defer rows.Close()
+
+	var tenders []model.Tender
+	for rows.Next() {
+		tender := model.Tender{}
+		if err := rows.Scan(
+			&tender.ID,
+			&tender.Name,
+			&tender.Description,
+			&tender.ServiceType,
+			&tender.OrganizationID,
+			&tender.CreatorUsername,
+			&tender.Status,
+			&tender.BudgetMin,
+			&tender.BudgetMax,
+			&tender.BudgetCurrency,
+			&tender.Deadline,
+			&tender.Auction,
+			&tender.AuctionEndsAt,
+			&tender.Version,
+			&tender.CreatedAt,
+			&tender.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan expired tender: %w", err)
+		}
+		tenders = append(tenders, tender)
+	}
+
+	return tenders, nil
+}
+
+func (r *tenderRepository) GetTenderByUsername(ctx context.Context, limit int, offset int, cursor *model.Cursor, username string) ([]model.Tender, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender
 		WHERE creator_username = $1
+		AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+		ORDER BY name, id
 		LIMIT $2 OFFSET $3
 	`)
 	if err != nil {
@@ -182,7 +284,8 @@ func (r *tenderRepository) GetTenderByUsername(ctx context.Context, limit int, o
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, username, limit, offset)
+	cursorName, cursorID := cursorArgs(cursor)
+	rows, err := stmt.QueryContext(ctx, username, limit, offset, cursorName, cursorID)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		return nil, fmt.Errorf("failed to execute query for getting tenders: %w", err)
@@ -201,6 +304,12 @@ func (r *tenderRepository) GetTenderByUsername(ctx context.Context, limit int, o
 			&tender.OrganizationID,
 			&tender.CreatorUsername,
 			&tender.Status,
+			&tender.BudgetMin,
+			&tender.BudgetMax,
+			&tender.BudgetCurrency,
+			&tender.Deadline,
+			&tender.Auction,
+			&tender.AuctionEndsAt,
 			&tender.Version,
 			&tender.CreatedAt,
 			&tender.UpdatedAt,
@@ -256,18 +365,55 @@ func (r *tenderRepository) UpdateTender(ctx context.Context, tender *model.Tende
 		}
 	}()
 
+	updatedTender, err := updateTender(ctx, tx, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updatedTender, nil
+}
+
+// updateTender сохраняет текущую версию тендера в историю и записывает новую в рамках транзакции
+func updateTender(ctx context.Context, tx *sql.Tx, tender *model.Tender) (*model.Tender, error) {
+	// Текущая версия сохраняется в историю до изменения
 	stmt1, err := tx.PrepareContext(ctx, `
+		INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at)
+		SELECT $1, id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $2 AND version = $3
+		RETURNING status
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for inserting tender history: %w", err)
+	}
+	defer stmt1.Close()
+
+	// Предыдущий статус нужен, чтобы определить тип события
+	var previousStatus model.TenderStatus
+	err = stmt1.QueryRowContext(ctx, uuid.New().String(), tender.ID, tender.Version).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionConflict
+		}
+		return nil, fmt.Errorf("failed to insert tender history: %w", err)
+	}
+
+	stmt2, err := tx.PrepareContext(ctx, `
 		UPDATE tender
-		SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-		WHERE id = $1
-		RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, budget_min = $8, budget_max = $9, budget_currency = $10, deadline = $11, version = $12, updated_at = $13
+		WHERE id = $1 AND version = $14
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement for updating tender: %w", err)
 	}
-	defer stmt1.Close()
+	defer stmt2.Close()
 
-	row1 := stmt1.QueryRowContext(ctx,
+	row := stmt2.QueryRowContext(ctx,
 		tender.ID,
 		tender.Name,
 		tender.Description,
@@ -275,12 +421,17 @@ func (r *tenderRepository) UpdateTender(ctx context.Context, tender *model.Tende
 		tender.OrganizationID,
 		tender.CreatorUsername,
 		tender.Status,
+		tender.BudgetMin,
+		tender.BudgetMax,
+		tender.BudgetCurrency,
+		tender.Deadline,
 		tender.Version+1,
 		time.Now(),
+		tender.Version,
 	)
 
 	var updatedTender model.Tender
-	if err := row1.Scan(
+	if err := row.Scan(
 		&updatedTender.ID,
 		&updatedTender.Name,
 		&updatedTender.Description,
@@ -288,46 +439,36 @@ func (r *tenderRepository) UpdateTender(ctx context.Context, tender *model.Tende
 		&updatedTender.OrganizationID,
 		&updatedTender.CreatorUsername,
 		&updatedTender.Status,
+		&updatedTender.BudgetMin,
+		&updatedTender.BudgetMax,
+		&updatedTender.BudgetCurrency,
+		&updatedTender.Deadline,
+		&updatedTender.Auction,
+		&updatedTender.AuctionEndsAt,
 		&updatedTender.Version,
 		&updatedTender.CreatedAt,
 		&updatedTender.UpdatedAt,
 	); err != nil {
+		// Строка не найдена - версию уже изменил другой запрос
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to scan updated tender: %w", err)
 	}
 
-	stmt2, err := tx.PrepareContext(ctx, `
-        INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`)
+	event, err := model.NewTenderEvent(previousStatus, &updatedTender)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare statement for inserting tender history: %w", err)
+		return nil, err
 	}
-	defer stmt2.Close()
-
-	_, err = stmt2.ExecContext(ctx,
-		uuid.New().String(),
-		tender.ID,
-		tender.Name,
-		tender.Description,
-		tender.ServiceType,
-		tender.Status,
-		tender.OrganizationID,
-		tender.CreatorUsername,
-		tender.Version,
-		tender.CreatedAt,
-		time.Now(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert tender history: %w", err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
 	}
 
 	return &updatedTender, nil
 }
-func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID string, version int) (*model.Tender, error) {
+
+// RollbackTenderVersion восстанавливает поля версии под блокировкой тендера; check проверяет итоговое состояние до записи
+func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID string, version int, check func(current *model.Tender, restored *model.Tender) error) (*model.Tender, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -341,9 +482,8 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 	}()
 
 	var historyTender model.Tender
-	fmt.Println(tenderID, version)
 	err = tx.QueryRowContext(ctx, `
-		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender_history
 		WHERE tender_id = $1 AND version = $2
 	`, tenderID, version).Scan(
@@ -354,6 +494,12 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 		&historyTender.OrganizationID,
 		&historyTender.CreatorUsername,
 		&historyTender.Status,
+		&historyTender.BudgetMin,
+		&historyTender.BudgetMax,
+		&historyTender.BudgetCurrency,
+		&historyTender.Deadline,
+		&historyTender.Auction,
+		&historyTender.AuctionEndsAt,
 		&historyTender.Version,
 		&historyTender.CreatedAt,
 		&historyTender.UpdatedAt,
@@ -365,11 +511,71 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 		return nil, fmt.Errorf("failed to get tender history: %w", err)
 	}
 
+	var current model.Tender
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1
+		FOR UPDATE
+	`, tenderID).Scan(
+		&current.ID,
+		&current.Name,
+		&current.Description,
+		&current.ServiceType,
+		&current.OrganizationID,
+		&current.CreatorUsername,
+		&current.Status,
+		&current.BudgetMin,
+		&current.BudgetMax,
+		&current.BudgetCurrency,
+		&current.Deadline,
+		&current.Auction,
+		&current.AuctionEndsAt,
+		&current.Version,
+		&current.CreatedAt,
+		&current.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock tender: %w", err)
+	}
+
+	// Статус и редукцион не откатываются, остальные поля берутся из версии
+	restored := current
+	restored.Name = historyTender.Name
+	restored.Description = historyTender.Description
+	restored.ServiceType = historyTender.ServiceType
+	restored.BudgetMin = historyTender.BudgetMin
+	restored.BudgetMax = historyTender.BudgetMax
+	restored.BudgetCurrency = historyTender.BudgetCurrency
+	restored.Deadline = historyTender.Deadline
+	if err := check(&current, &restored); err != nil {
+		return nil, err
+	}
+
+	// Откат - это новая правка: текущее состояние уходит в историю
+	var currentVersion int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at)
+		SELECT $1, id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $2
+		RETURNING version
+	`, uuid.New().String(), tenderID).Scan(&currentVersion)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("failed to insert tender history: %w", err)
+	}
+
 	stmt, err := tx.PrepareContext(ctx, `
 		UPDATE tender
-		SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-		WHERE id = $1
-		RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		SET name = $2, description = $3, service_type = $4, budget_min = $5, budget_max = $6, budget_currency = $7, deadline = $8, version = $9, updated_at = $10
+		WHERE id = $1 AND version = $11
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare statement for updating tender: %w", err)
@@ -377,15 +583,17 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 	defer stmt.Close()
 
 	row := stmt.QueryRowContext(ctx,
-		historyTender.ID,
-		historyTender.Name,
-		historyTender.Description,
-		historyTender.ServiceType,
-		historyTender.OrganizationID,
-		historyTender.CreatorUsername,
-		historyTender.Status,
-		historyTender.Version,
+		tenderID,
+		restored.Name,
+		restored.Description,
+		restored.ServiceType,
+		restored.BudgetMin,
+		restored.BudgetMax,
+		restored.BudgetCurrency,
+		restored.Deadline,
+		currentVersion+1,
 		time.Now(),
+		currentVersion,
 	)
 
 	var updatedTender model.Tender
@@ -397,16 +605,30 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 		&updatedTender.OrganizationID,
 		&updatedTender.CreatorUsername,
 		&updatedTender.Status,
+		&updatedTender.BudgetMin,
+		&updatedTender.BudgetMax,
+		&updatedTender.BudgetCurrency,
+		&updatedTender.Deadline,
+		&updatedTender.Auction,
+		&updatedTender.AuctionEndsAt,
 		&updatedTender.Version,
 		&updatedTender.CreatedAt,
 		&updatedTender.UpdatedAt,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, model.ErrTenderNotFound
+			return nil, model.ErrVersionConflict
 		}
 		return nil, fmt.Errorf("failed to scan updated tender: %w", err)
 	}
 
+	event, err := model.NewTenderEvent(updatedTender.Status, &updatedTender)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -414,3 +636,357 @@ func (r *tenderRepository) RollbackTenderVersion(ctx context.Context, tenderID s
 	return &updatedTender, nil
 }
 
+func (r *tenderRepository) GetTenderVersions(ctx context.Context, tenderID string, limit int, offset int) ([]model.Tender, error) {
+	// Текущая версия хранится в tender, предыдущие - в tender_history
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, service_type::text, organization_id, creator_username, status::text, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1
+		UNION ALL
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender_history
+		WHERE tender_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting tender versions: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, tenderID, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting tender versions", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting tender versions: %w", err)
+	}
+	defer rows.Close()
+
+	var tenders []model.Tender
+	for rows.Next() {
+		tender := model.Tender{}
+		if err := rows.Scan(
+			&tender.ID,
+			&tender.Name,
+			&tender.Description,
+			&tender.ServiceType,
+			&tender.OrganizationID,
+			&tender.CreatorUsername,
+			&tender.Status,
+			&tender.BudgetMin,
+			&tender.BudgetMax,
+			&tender.BudgetCurrency,
+			&tender.Deadline,
+			&tender.Auction,
+			&tender.AuctionEndsAt,
+			&tender.Version,
+			&tender.CreatedAt,
+			&tender.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan tender version: %w", err)
+		}
+		tenders = append(tenders, tender)
+	}
+
+	return tenders, nil
+}
+
+func (r *tenderRepository) GetTenderVersion(ctx context.Context, tenderID string, version int) (*model.Tender, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, name, description, service_type::text, organization_id, creator_username, status::text, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1 AND version = $2
+		UNION ALL
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender_history
+		WHERE tender_id = $1 AND version = $2
+		LIMIT 1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting tender version: %w", err)
+	}
+	defer stmt.Close()
+
+	tender := model.Tender{}
+	err = stmt.QueryRowContext(ctx, tenderID, version).Scan(
+		&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.OrganizationID,
+		&tender.CreatorUsername,
+		&tender.Status,
+		&tender.BudgetMin,
+		&tender.BudgetMax,
+		&tender.BudgetCurrency,
+		&tender.Deadline,
+		&tender.Auction,
+		&tender.AuctionEndsAt,
+		&tender.Version,
+		&tender.CreatedAt,
+		&tender.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVersionNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error getting tender version", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting tender version: %w", err)
+	}
+
+	return &tender, nil
+}
+
+func (r *tenderRepository) SearchTenders(ctx context.Context, query string, limit int, offset int, filter model.TenderFilter, username string) ([]model.TenderSearchResult, error) {
+	// Данные в основном на русском, поэтому запрос разбирается обеими конфигурациями
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at,
+			ts_rank(t.search_vector, q.query) AS rank,
+			ts_headline('russian', t.name || ' ' || coalesce(t.description, ''), q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM tender t
+		CROSS JOIN (SELECT websearch_to_tsquery('russian', $10) || websearch_to_tsquery('english', $10) AS query) q
+		WHERE t.search_vector @@ q.query
+		AND `+tenderFilterCondition+`
+		AND (
+			t.status = 'Published'
+			OR EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $9
+			)
+		)
+		ORDER BY rank DESC, t.id
+		LIMIT $11 OFFSET $12
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for searching tenders: %w", err)
+	}
+	defer stmt.Close()
+
+	args := append(tenderFilterArgs(filter), username, query, limit, offset)
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error searching tenders", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for searching tenders: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.TenderSearchResult
+	for rows.Next() {
+		result := model.TenderSearchResult{}
+		if err := rows.Scan(
+			&result.ID,
+			&result.Name,
+			&result.Description,
+			&result.ServiceType,
+			&result.OrganizationID,
+			&result.CreatorUsername,
+			&result.Status,
+			&result.BudgetMin,
+			&result.BudgetMax,
+			&result.BudgetCurrency,
+			&result.Deadline,
+			&result.Auction,
+			&result.AuctionEndsAt,
+			&result.Version,
+			&result.CreatedAt,
+			&result.UpdatedAt,
+			&result.Rank,
+			&result.Snippet,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan tender search result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Критерии с теми же названиями обновляются, чтобы не потерять выставленные по ним оценки
+func (r *tenderRepository) SetTenderCriteria(ctx context.Context, tenderID string, criteria []model.TenderCriterion) ([]model.TenderCriterion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if err != sql.ErrTxDone && err != sql.ErrConnDone {
+				r.logger.ErrorContext(ctx, "Error rolling back transaction", slog.Any("error", err))
+			}
+		}
+	}()
+
+	names := make([]string, len(criteria))
+	for i, criterion := range criteria {
+		names[i] = criterion.Name
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tender_criterion
+		WHERE tender_id = $1 AND NOT (name = ANY($2))
+	`, tenderID, pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tender criteria: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO tender_criterion (id, tender_id, name, weight, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tender_id, name) DO UPDATE
+		SET weight = EXCLUDED.weight
+		RETURNING id, tender_id, name, weight, created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for saving tender criterion: %w", err)
+	}
+	defer stmt.Close()
+
+	var saved []model.TenderCriterion
+	for _, criterion := range criteria {
+		var savedCriterion model.TenderCriterion
+		err := stmt.QueryRowContext(ctx, criterion.ID, tenderID, criterion.Name, criterion.Weight, criterion.CreatedAt).Scan(
+			&savedCriterion.ID,
+			&savedCriterion.TenderID,
+			&savedCriterion.Name,
+			&savedCriterion.Weight,
+			&savedCriterion.CreatedAt,
+		)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error saving tender criterion", slog.Any("error", err))
+			return nil, fmt.Errorf("failed to save tender criterion: %w", err)
+		}
+		saved = append(saved, savedCriterion)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *tenderRepository) GetTenderCriteria(ctx context.Context, tenderID string) ([]model.TenderCriterion, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, tender_id, name, weight, created_at
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting tender criteria: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, tenderID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting tender criteria", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting tender criteria: %w", err)
+	}
+	defer rows.Close()
+
+	var criteria []model.TenderCriterion
+	for rows.Next() {
+		criterion := model.TenderCriterion{}
+		if err := rows.Scan(&criterion.ID, &criterion.TenderID, &criterion.Name, &criterion.Weight, &criterion.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tender criterion: %w", err)
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, nil
+}
+
+func (r *tenderRepository) GetAuction(ctx context.Context, tenderID string) (*model.Auction, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT t.id, t.auction_ends_at, w.price, w.currency,
+			(SELECT COUNT(*) FROM bid b WHERE b.tender_id = t.id AND b.status = 'Published' AND b.price IS NOT NULL AND b.currency = t.budget_currency),
+			ar.bid_id, ar.price, ar.currency, ar.finished_at
+		FROM tender t
+		LEFT JOIN LATERAL (
+			SELECT price, currency
+			FROM bid
+			WHERE tender_id = t.id AND status = 'Published' AND price IS NOT NULL AND currency = t.budget_currency
+			ORDER BY price, updated_at, id
+			LIMIT 1
+		) w ON true
+		LEFT JOIN auction_result ar ON ar.tender_id = t.id
+		WHERE t.id = $1 AND t.auction
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting auction: %w", err)
+	}
+	defer stmt.Close()
+
+	auction := &model.Auction{}
+	result := &model.AuctionResult{}
+	var finishedAt *time.Time
+	err = stmt.QueryRowContext(ctx, tenderID).Scan(
+		&auction.TenderID,
+		&auction.EndsAt,
+		&auction.LowestPrice,
+		&auction.Currency,
+		&auction.BidsCount,
+		&result.BidID,
+		&result.Price,
+		&result.Currency,
+		&finishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrNotAuction
+		}
+		r.logger.ErrorContext(ctx, "Error getting auction", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+
+	if finishedAt != nil {
+		result.TenderID = auction.TenderID
+		result.FinishedAt = *finishedAt
+		auction.Result = result
+	}
+
+	return auction, nil
+}
+
+// FinishAuctions фиксирует победителя каждого завершившегося редукциона, повторный вызов ничего не меняет.
+// Учитываются только опубликованные тендеры, цены сравниваются в валюте бюджета
+func (r *tenderRepository) FinishAuctions(ctx context.Context, now time.Time) ([]model.AuctionResult, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO auction_result (tender_id, bid_id, price, currency, finished_at)
+		SELECT t.id, w.id, w.price, w.currency, $1
+		FROM tender t
+		LEFT JOIN LATERAL (
+			SELECT id, price, currency
+			FROM bid
+			WHERE tender_id = t.id AND status = 'Published' AND price IS NOT NULL AND currency = t.budget_currency
+			ORDER BY price, updated_at, id
+			LIMIT 1
+		) w ON true
+		WHERE t.auction AND t.status = 'Published' AND t.auction_ends_at <= $1
+			AND NOT EXISTS (SELECT 1 FROM auction_result ar WHERE ar.tender_id = t.id)
+		ON CONFLICT (tender_id) DO NOTHING
+		RETURNING tender_id, bid_id, price, currency, finished_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for finishing auctions: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, now)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error finishing auctions", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for finishing auctions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.AuctionResult
+	for rows.Next() {
+		result := model.AuctionResult{}
+		if err := rows.Scan(&result.TenderID, &result.BidID, &result.Price, &result.Currency, &result.FinishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan auction result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -36,6 +36,8 @@ func TestCreateTender(t *testing.T) {
 
 		defer db.Close()
 
+		budgetMin, budgetMax, currency := model.Decimal("1000.00"), model.Decimal("5000.00"), "RUB"
+		deadline := time.Now().Add(24 * time.Hour)
 		tender := model.Tender{
 			ID:              uuid.New().String(),
 			Name:            "Test Tender",
@@ -44,14 +46,18 @@ func TestCreateTender(t *testing.T) {
 			OrganizationID:  uuid.New().String(),
 			CreatorUsername: "testuser",
 			Status:          "test",
+			BudgetMin:       &budgetMin,
+			BudgetMax:       &budgetMax,
+			BudgetCurrency:  &currency,
+			Deadline:        &deadline,
 			Version:         1,
 		}
 
 		mock.ExpectBegin()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO tender (id, name, description, service_type, organization_id, creator_username, status, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at`)).
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO tender (id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at`)).
 			ExpectQuery().WithArgs(
 			tender.ID,
 			tender.Name,
@@ -60,10 +66,17 @@ func TestCreateTender(t *testing.T) {
 			tender.OrganizationID,
 			tender.CreatorUsername,
 			tender.Status,
+			budgetMin,
+			budgetMax,
+			currency,
+			deadline,
+			false,
+			nil,
 			tender.Version,
 		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at"}).
-			AddRow(tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, tender.Version, time.Now(), time.Now()))
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at"}).
+			AddRow(tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, []byte("1000.00"), []byte("5000.00"), currency, deadline, false, nil, tender.Version, time.Now(), time.Now()))
+		expectInsertEvent(mock, model.EventTenderCreated, tender.ID, tender.ID)
 
 		mock.ExpectCommit()
 
@@ -72,6 +85,7 @@ func TestCreateTender(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, createdTender)
 		assert.Equal(t, tender.Name, createdTender.Name)
+		assert.Equal(t, model.Decimal("5000.00"), *createdTender.BudgetMax)
 		assert.NotEmpty(t, createdTender.UpdatedAt)
 		assert.NotEmpty(t, createdTender.CreatedAt)
 
@@ -137,6 +151,34 @@ func TestCreateTender(t *testing.T) {
 }
 
 func TestGetTenders(t *testing.T) {
+	tendersQuery := func(sortColumn string, comparison string, castType string, order string) string {
+		return regexp.QuoteMeta(fmt.Sprintf(`
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at
+		FROM tender t
+		WHERE (cardinality($1::text[]) = 0 OR t.service_type::text = ANY($1))
+		AND (cardinality($2::text[]) = 0 OR t.status::text = ANY($2))
+		AND ($3::varchar IS NULL OR t.organization_id = $3)
+		AND ($4::varchar IS NULL OR t.creator_username = $4)
+		AND ($5::timestamptz IS NULL OR t.created_at >= $5)
+		AND ($6::timestamptz IS NULL OR t.created_at <= $6)
+		AND ($7::timestamptz IS NULL OR t.updated_at >= $7)
+		AND ($8::timestamptz IS NULL OR t.updated_at <= $8)
+		AND (
+			t.status = 'Published'
+			OR EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $9
+			)
+		)
+		AND ($10::text IS NULL OR (%[1]s, t.id) %[2]s ($10::%[3]s, $11))
+		ORDER BY %[1]s %[4]s, t.id %[4]s
+		LIMIT $12 OFFSET $13
+	`, sortColumn, comparison, castType, order))
+	}
+	getTendersQuery := tendersQuery("t.name", ">", "varchar", "ASC")
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
@@ -145,19 +187,20 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		serviceTypes := []model.TenderServiceType{model.TenderServiceTypeConstruction, model.TenderServiceTypeDelivery, model.TenderServiceTypeManufacture}
 
-		expectedQuery := mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`)
+		expectedQuery := mock.ExpectPrepare(getTendersQuery)
 
 		expectedQuery.ExpectQuery().
-			WithArgs(pq.Array(serviceTypes), limit, offset).
+			WithArgs(pq.Array(serviceTypes), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, username, nil, nil, limit, offset).
 			WillReturnRows(sqlmock.NewRows([]string{
-				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
 			}).AddRow(
-				tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, tender.Version, time.Now(), time.Now(),
+				tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, nil, nil, nil, nil, false, nil, tender.Version, time.Now(), time.Now(),
 			))
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, tenders)
@@ -176,6 +219,7 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		serviceTypes := []model.TenderServiceType{model.TenderServiceTypeConstruction}
 
 		serviceTypeStrings := make([]string, len(serviceTypes))
@@ -183,13 +227,13 @@ func TestGetTenders(t *testing.T) {
 			serviceTypeStrings[i] = string(st)
 		}
 
-		expectedQuery := mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`)
+		expectedQuery := mock.ExpectPrepare(getTendersQuery)
 
 		expectedQuery.ExpectQuery().
-			WithArgs(pq.Array(serviceTypeStrings), limit, offset).
+			WithArgs(pq.Array(serviceTypeStrings), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, username, nil, nil, limit, offset).
 			WillReturnError(sql.ErrNoRows)
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, sql.ErrNoRows), "expected sql.ErrNoRows, but got: %v", err)
@@ -207,12 +251,13 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		serviceTypes := []model.TenderServiceType{model.TenderServiceTypeConstruction}
 
-		mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`).
+		mock.ExpectPrepare(getTendersQuery).
 			WillReturnError(fmt.Errorf("some error"))
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -229,6 +274,7 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		serviceTypes := []model.TenderServiceType{model.TenderServiceTypeConstruction}
 
 		serviceTypeStrings := make([]string, len(serviceTypes))
@@ -236,13 +282,13 @@ func TestGetTenders(t *testing.T) {
 			serviceTypeStrings[i] = string(st)
 		}
 
-		expectedQuery := mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`)
+		expectedQuery := mock.ExpectPrepare(getTendersQuery)
 
 		expectedQuery.ExpectQuery().
-			WithArgs(pq.Array(serviceTypeStrings), limit, offset).
+			WithArgs(pq.Array(serviceTypeStrings), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, username, nil, nil, limit, offset).
 			WillReturnError(fmt.Errorf("some query error"))
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -259,6 +305,7 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		serviceTypes := []model.TenderServiceType{model.TenderServiceTypeConstruction}
 
 		serviceTypeStrings := make([]string, len(serviceTypes))
@@ -266,17 +313,17 @@ func TestGetTenders(t *testing.T) {
 			serviceTypeStrings[i] = string(st)
 		}
 
-		expectedQuery := mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`)
+		expectedQuery := mock.ExpectPrepare(getTendersQuery)
 
 		expectedQuery.ExpectQuery().
-			WithArgs(pq.Array(serviceTypeStrings), limit, offset).
+			WithArgs(pq.Array(serviceTypeStrings), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, username, nil, nil, limit, offset).
 			WillReturnRows(sqlmock.NewRows([]string{
-				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at",
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at",
 			}).AddRow( // Missing "updated_at"
-				"1", "Test Tender", "Description", "Construction", "123", "user1", "Active", 1, time.Now(),
+				"1", "Test Tender", "Description", "Construction", "123", "user1", "Active", nil, nil, nil, nil, false, nil, 1, time.Now(),
 			))
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -293,25 +340,61 @@ func TestGetTenders(t *testing.T) {
 		ctx := context.Background()
 		limit := 10
 		offset := 0
+		username := "testuser"
 		var serviceTypes []model.TenderServiceType
 
-		expectedQuery := mock.ExpectPrepare(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at FROM tender WHERE service_type = ANY\(\$1\) LIMIT \$2 OFFSET \$3`)
+		expectedQuery := mock.ExpectPrepare(getTendersQuery)
 
 		expectedQuery.ExpectQuery().
-			WithArgs(pq.Array([]string{}), limit, offset). // Pass an empty string array
+			WithArgs(pq.Array([]string{}), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, username, nil, nil, limit, offset). // Pass an empty string array
 			WillReturnRows(sqlmock.NewRows([]string{
-				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
-			}))
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+			}).AddRow(
+				"1", "Test Tender", "Description", "Delivery", "123", "user1", "Published", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now(),
+			))
 
-		tenders, err := repo.GetTenders(ctx, limit, offset, serviceTypes)
+		tenders, err := repo.GetTenders(ctx, limit, offset, nil, model.TenderFilter{ServiceTypes: serviceTypes, Sort: model.TenderSortName}, username)
 
+		// Пустой список типов услуг не ограничивает выборку
 		assert.NoError(t, err)
-		assert.Empty(t, tenders) // Expect an empty slice, not nil
+		assert.Len(t, tenders, 1)
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("there were unfulfilled expectations: %s", err)
 		}
 	})
+
+	t.Run("filters_and_sort", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cursorTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		filter := model.TenderFilter{
+			Statuses:        []model.TenderStatus{model.TenderStatusPublished, model.TenderStatusClosed},
+			OrganizationID:  "org-id",
+			CreatorUsername: "user1",
+			CreatedFrom:     &createdFrom,
+			Sort:            model.TenderSortCreatedAt,
+			Desc:            true,
+		}
+		cursor := &model.Cursor{Value: cursorTime.Format(time.RFC3339Nano), ID: "tender-id"}
+
+		mock.ExpectPrepare(tendersQuery("t.created_at", "<", "timestamptz", "DESC")).ExpectQuery().
+			WithArgs(pq.Array([]string{}), pq.Array([]string{"Published", "Closed"}), "org-id", "user1", createdFrom, nil, nil, nil, "testuser", cursor.Value, "tender-id", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+			}).AddRow(
+				"1", "Test Tender", "Description", "Construction", "org-id", "user1", "Closed", nil, nil, nil, nil, false, nil, 1, createdFrom, createdFrom,
+			))
+
+		tenders, err := repo.GetTenders(ctx, 10, 0, cursor, filter, "testuser")
+
+		assert.NoError(t, err)
+		assert.Len(t, tenders, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestGetTenderById(t *testing.T) {
@@ -322,14 +405,14 @@ func TestGetTenderById(t *testing.T) {
 		id := "test"
 		ctx := context.Background()
 
-		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender
 		WHERE id = $1`))
 
 		expectQuery.ExpectQuery().WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
 		}).AddRow(
-			"id", "Test Tender", "Description", "Construction", "123", "user1", "Active", 1, time.Now(), time.Now()))
+			"id", "Test Tender", "Description", "Construction", "123", "user1", "Active", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now()))
 
 		tender, err := repo.GetTenderById(ctx, id)
 
@@ -348,7 +431,7 @@ func TestGetTenderById(t *testing.T) {
 		id := "test"
 		ctx := context.Background()
 
-		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender
 		WHERE id = $1`))
 
@@ -370,7 +453,7 @@ func TestGetTenderById(t *testing.T) {
 		id := "test"
 		ctx := context.Background()
 
-		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+		expectQuery := mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 		FROM tender
 		WHERE id = $1`))
 
@@ -396,21 +479,23 @@ func TestGetTenderByUsername(t *testing.T) {
 		ctx := context.Background()
 
 		expectedQuery := mock.ExpectPrepare(regexp.QuoteMeta(`
-			SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+			SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 			FROM tender
 			WHERE creator_username = $1
+			AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+			ORDER BY name, id
 			LIMIT $2 OFFSET $3
 		`))
 
 		expectedQuery.ExpectQuery().
-			WithArgs(username, limit, offset).
+			WithArgs(username, limit, offset, nil, nil).
 			WillReturnRows(sqlmock.NewRows([]string{
-				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
 			}).AddRow(
-				"1", "Test Tender", "Description", "ServiceType", "OrgID", username, "Status", 1, time.Now(), time.Now(),
+				"1", "Test Tender", "Description", "ServiceType", "OrgID", username, "Status", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now(),
 			))
 
-		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, username)
+		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, nil, username)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, tenders)
@@ -432,13 +517,15 @@ func TestGetTenderByUsername(t *testing.T) {
 		ctx := context.Background()
 
 		mock.ExpectPrepare(regexp.QuoteMeta(`
-			SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+			SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 			FROM tender
 			WHERE creator_username = $1
+			AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+			ORDER BY name, id
 			LIMIT $2 OFFSET $3
 		`)).WillReturnError(fmt.Errorf("prepare statement error"))
 
-		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, username)
+		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, nil, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -459,17 +546,19 @@ func TestGetTenderByUsername(t *testing.T) {
 		ctx := context.Background()
 
 		expectedQuery := mock.ExpectPrepare(regexp.QuoteMeta(`
-			SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+			SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 			FROM tender
 			WHERE creator_username = $1
+			AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+			ORDER BY name, id
 			LIMIT $2 OFFSET $3
 		`))
 
 		expectedQuery.ExpectQuery().
-			WithArgs(username, limit, offset).
+			WithArgs(username, limit, offset, nil, nil).
 			WillReturnError(fmt.Errorf("query error"))
 
-		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, username)
+		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, nil, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -490,21 +579,23 @@ func TestGetTenderByUsername(t *testing.T) {
 		ctx := context.Background()
 
 		expectedQuery := mock.ExpectPrepare(regexp.QuoteMeta(`
-			SELECT id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
+			SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
 			FROM tender
 			WHERE creator_username = $1
+			AND ($4::varchar IS NULL OR (name, id) > ($4, $5))
+			ORDER BY name, id
 			LIMIT $2 OFFSET $3
 		`))
 
 		expectedQuery.ExpectQuery().
-			WithArgs(username, limit, offset).
+			WithArgs(username, limit, offset, nil, nil).
 			WillReturnRows(sqlmock.NewRows([]string{
-				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at",
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at",
 			}).AddRow( // Missing "updated_at"
-				"1", "Test Tender", "Description", "ServiceType", "OrgID", username, "Status", 1, time.Now(),
+				"1", "Test Tender", "Description", "ServiceType", "OrgID", username, "Status", nil, nil, nil, nil, false, nil, 1, time.Now(),
 			))
 
-		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, username)
+		tenders, err := repo.GetTenderByUsername(ctx, limit, offset, nil, username)
 
 		assert.Error(t, err)
 		assert.Nil(t, tenders)
@@ -644,11 +735,22 @@ func TestIsUserResponsibleForTender(t *testing.T) {
 
 }
 func TestUpdateTender(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		db, mock, repo := setupTest(t)
-		defer db.Close()
-
-		tender := model.Tender{
+	historyQuery := regexp.QuoteMeta(`
+		INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at)
+		SELECT $1, id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $2 AND version = $3
+		RETURNING status
+	`)
+	updateQuery := regexp.QuoteMeta(`
+		UPDATE tender
+		SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, budget_min = $8, budget_max = $9, budget_currency = $10, deadline = $11, version = $12, updated_at = $13
+		WHERE id = $1 AND version = $14
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+	`)
+
+	newTender := func() model.Tender {
+		return model.Tender{
 			ID:              uuid.New().String(),
 			Name:            "Test Tender",
 			Description:     "Test Description",
@@ -660,15 +762,23 @@ func TestUpdateTender(t *testing.T) {
 			CreatedAt:       time.Now(),
 			UpdatedAt:       time.Now(),
 		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tender := newTender()
 
 		mock.ExpectBegin()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			UPDATE tender
-			SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-			WHERE id = $1
-			RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-		`)).ExpectQuery().WithArgs(
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WithArgs(
+			sqlmock.AnyArg(),
+			tender.ID,
+			tender.Version,
+		).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("test"))
+
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WithArgs(
 			tender.ID,
 			tender.Name,
 			tender.Description,
@@ -676,30 +786,19 @@ func TestUpdateTender(t *testing.T) {
 			tender.OrganizationID,
 			tender.CreatorUsername,
 			tender.Status,
+			nil,
+			nil,
+			nil,
+			nil,
 			tender.Version+1,
 			sqlmock.AnyArg(),
+			tender.Version,
 		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
 		}).AddRow(
-			tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, tender.Version+1, tender.CreatedAt, time.Now(),
+			tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, nil, nil, nil, nil, false, nil, tender.Version+1, tender.CreatedAt, time.Now(),
 		))
-
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		`)).ExpectExec().WithArgs(
-			sqlmock.AnyArg(),
-			tender.ID,
-			tender.Name,
-			tender.Description,
-			tender.ServiceType,
-			tender.Status,
-			tender.OrganizationID,
-			tender.CreatorUsername,
-			tender.Version,
-			tender.CreatedAt,
-			sqlmock.AnyArg(),
-		).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectInsertEvent(mock, model.EventTenderUpdated, tender.ID, tender.ID)
 
 		mock.ExpectCommit()
 
@@ -715,6 +814,23 @@ func TestUpdateTender(t *testing.T) {
 		}
 	})
 
+	t.Run("version_conflict", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tender := &model.Tender{ID: uuid.New().String(), Name: "Test Tender", Version: 2}
+
+		mock.ExpectBegin()
+		// Версия уже изменилась - история не записывается, до обновления дело не доходит
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}))
+		mock.ExpectRollback()
+
+		_, err := repo.UpdateTender(context.Background(), tender)
+		assert.Equal(t, model.ErrVersionConflict, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("begin_transaction_error", func(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
@@ -739,6 +855,7 @@ func TestUpdateTender(t *testing.T) {
 		tender := &model.Tender{ID: uuid.New().String(), Name: "Test Tender"}
 
 		mock.ExpectBegin()
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("test"))
 		mock.ExpectPrepare(regexp.QuoteMeta(`UPDATE tender`)).WillReturnError(errors.New("prepare statement error"))
 		mock.ExpectRollback()
 
@@ -758,6 +875,7 @@ func TestUpdateTender(t *testing.T) {
 		tender := &model.Tender{ID: uuid.New().String(), Name: "Test Tender"}
 
 		mock.ExpectBegin()
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("test"))
 		mock.ExpectPrepare(regexp.QuoteMeta(`UPDATE tender`)).
 			ExpectQuery().
 			WillReturnError(errors.New("query error"))
@@ -776,57 +894,14 @@ func TestUpdateTender(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
 
-		tender := model.Tender{
-			ID:              uuid.New().String(),
-			Name:            "Test Tender",
-			Description:     "Test Description",
-			ServiceType:     "Test Service",
-			OrganizationID:  uuid.New().String(),
-			CreatorUsername: "testuser",
-			Status:          "test",
-			Version:         1,
-			CreatedAt:       time.Now(),
-			UpdatedAt:       time.Now(),
-		}
+		tender := newTender()
 
 		mock.ExpectBegin()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			UPDATE tender
-			SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-			WHERE id = $1
-			RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-		`)).ExpectQuery().WithArgs(
-			tender.ID,
-			tender.Name,
-			tender.Description,
-			tender.ServiceType,
-			tender.OrganizationID,
-			tender.CreatorUsername,
-			tender.Status,
-			tender.Version+1,
-			sqlmock.AnyArg(),
-		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
-		}).AddRow(
-			tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, tender.Version+1, tender.CreatedAt, time.Now(),
-		))
-
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		`)).ExpectExec().WithArgs(
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WithArgs(
 			sqlmock.AnyArg(),
 			tender.ID,
-			tender.Name,
-			tender.Description,
-			tender.ServiceType,
-			tender.Status,
-			tender.OrganizationID,
-			tender.CreatorUsername,
 			tender.Version,
-			tender.CreatedAt,
-			sqlmock.AnyArg(),
 		).WillReturnError(errors.New("insert history error"))
 		mock.ExpectRollback()
 
@@ -843,58 +918,22 @@ func TestUpdateTender(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
 
-		tender := model.Tender{
-			ID:              uuid.New().String(),
-			Name:            "Test Tender",
-			Description:     "Test Description",
-			ServiceType:     "Test Service",
-			OrganizationID:  uuid.New().String(),
-			CreatorUsername: "testuser",
-			Status:          "test",
-			Version:         1,
-			CreatedAt:       time.Now(),
-			UpdatedAt:       time.Now(),
-		}
+		tender := newTender()
 
 		mock.ExpectBegin()
 
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			UPDATE tender
-			SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-			WHERE id = $1
-			RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-		`)).ExpectQuery().WithArgs(
-			tender.ID,
-			tender.Name,
-			tender.Description,
-			tender.ServiceType,
-			tender.OrganizationID,
-			tender.CreatorUsername,
-			tender.Status,
-			tender.Version+1,
-			sqlmock.AnyArg(),
-		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
-		}).AddRow(
-			tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, tender.Version+1, tender.CreatedAt, time.Now(),
-		))
-
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, version, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		`)).ExpectExec().WithArgs(
+		mock.ExpectPrepare(historyQuery).ExpectQuery().WithArgs(
 			sqlmock.AnyArg(),
 			tender.ID,
-			tender.Name,
-			tender.Description,
-			tender.ServiceType,
-			tender.Status,
-			tender.OrganizationID,
-			tender.CreatorUsername,
 			tender.Version,
-			tender.CreatedAt,
-			sqlmock.AnyArg(),
-		).WillReturnResult(sqlmock.NewResult(1, 1))
+		).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("test"))
+
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+		}).AddRow(
+			tender.ID, tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorUsername, tender.Status, nil, nil, nil, nil, false, nil, tender.Version+1, tender.CreatedAt, time.Now(),
+		))
+		expectInsertEvent(mock, model.EventTenderUpdated, tender.ID, tender.ID)
 
 		mock.ExpectCommit().WillReturnError(errors.New("commit error"))
 
@@ -907,120 +946,646 @@ func TestUpdateTender(t *testing.T) {
 		}
 	})
 }
+
 func TestRollbackTenderVersion(t *testing.T) {
+	historyQuery := regexp.QuoteMeta(`
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender_history
+		WHERE tender_id = $1 AND version = $2
+	`)
+	lockQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1
+		FOR UPDATE
+	`)
+	snapshotQuery := regexp.QuoteMeta(`
+		INSERT INTO tender_history (id, tender_id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at)
+		SELECT $1, id, name, description, service_type, status, organization_id, creator_username, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $2
+		RETURNING version
+	`)
+	updateQuery := regexp.QuoteMeta(`
+		UPDATE tender
+		SET name = $2, description = $3, service_type = $4, budget_min = $5, budget_max = $6, budget_currency = $7, deadline = $8, version = $9, updated_at = $10
+		WHERE id = $1 AND version = $11
+		RETURNING id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+	`)
+	tenderColumns := []string{
+		"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+	}
+
+	tenderID := "test-tender-id"
+	version := 1
+	historyTender := model.Tender{
+		ID:              tenderID,
+		Name:            "Test Tender",
+		Description:     "Test Description",
+		ServiceType:     "Construction",
+		OrganizationID:  "test-org-id",
+		CreatorUsername: "testuser",
+		Status:          "Created",
+		Version:         version,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	historyRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(tenderColumns).AddRow(
+			historyTender.ID, historyTender.Name, historyTender.Description, historyTender.ServiceType, historyTender.OrganizationID, historyTender.CreatorUsername, historyTender.Status, []byte("1000.00"), nil, "RUB", nil, false, nil, historyTender.Version, historyTender.CreatedAt, historyTender.UpdatedAt,
+		)
+	}
+
+	currentRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(tenderColumns).AddRow(
+			tenderID, "Current Tender", "Current Description", "Delivery", historyTender.OrganizationID, historyTender.CreatorUsername, "Published", nil, nil, nil, nil, false, nil, 3, historyTender.CreatedAt, historyTender.UpdatedAt,
+		)
+	}
+	noCheck := func(*model.Tender, *model.Tender) error { return nil }
+
 	t.Run("success", func(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
 
-		tenderID := "test-tender-id"
-		version := 1
 		ctx := context.Background()
 
-		historyTender := model.Tender{
-			ID:              tenderID,
-			Name:            "Test Tender",
-			Description:     "Test Description",
-			ServiceType:     "Test Service",
-			OrganizationID:  "test-org-id",
-			CreatorUsername: "testuser",
-			Status:          "test",
-			Version:         version,
-			CreatedAt:       time.Now(),
-			UpdatedAt:       time.Now(),
-		}
-
 		mock.ExpectBegin()
-
-		mock.ExpectQuery(regexp.QuoteMeta(`
-			SELECT tender_id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-			FROM tender_history
-			WHERE tender_id = $1 AND version = $2
-		`)).WithArgs(tenderID, version).
-			WillReturnRows(sqlmock.NewRows([]string{
-				"tender_id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
-			}).AddRow(
-				historyTender.ID, historyTender.Name, historyTender.Description, historyTender.ServiceType, historyTender.OrganizationID, historyTender.CreatorUsername, historyTender.Status, historyTender.Version, historyTender.CreatedAt, historyTender.UpdatedAt,
-			))
-
-		mock.ExpectPrepare(regexp.QuoteMeta(`
-			UPDATE tender
-			SET name = $2, description = $3, service_type = $4, organization_id = $5, creator_username = $6, status = $7, version = $8, updated_at = $9
-			WHERE id = $1
-			RETURNING id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-		`)).ExpectQuery().WithArgs(
-			historyTender.ID,
+		mock.ExpectQuery(historyQuery).WithArgs(tenderID, version).WillReturnRows(historyRow())
+		mock.ExpectQuery(lockQuery).WithArgs(tenderID).WillReturnRows(currentRow())
+		mock.ExpectQuery(snapshotQuery).WithArgs(sqlmock.AnyArg(), tenderID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().WithArgs(
+			tenderID,
 			historyTender.Name,
 			historyTender.Description,
 			historyTender.ServiceType,
-			historyTender.OrganizationID,
-			historyTender.CreatorUsername,
-			historyTender.Status,
-			historyTender.Version,
+			"1000.00",
+			nil,
+			"RUB",
+			nil,
+			4,
 			sqlmock.AnyArg(),
-		).WillReturnRows(sqlmock.NewRows([]string{
-			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "version", "created_at", "updated_at",
-		}).AddRow(
-			historyTender.ID, historyTender.Name, historyTender.Description, historyTender.ServiceType, historyTender.OrganizationID, historyTender.CreatorUsername, historyTender.Status, historyTender.Version, historyTender.CreatedAt, time.Now(),
+			3,
+		).WillReturnRows(sqlmock.NewRows(tenderColumns).AddRow(
+			tenderID, historyTender.Name, historyTender.Description, historyTender.ServiceType, historyTender.OrganizationID, historyTender.CreatorUsername, "Published", []byte("1000.00"), nil, "RUB", nil, false, nil, 4, historyTender.CreatedAt, time.Now(),
 		))
-
+		expectInsertEvent(mock, model.EventTenderUpdated, tenderID, tenderID)
 		mock.ExpectCommit()
 
-		updatedTender, err := repo.RollbackTenderVersion(ctx, tenderID, version)
+		var restored model.Tender
+		updatedTender, err := repo.RollbackTenderVersion(ctx, tenderID, version, func(current *model.Tender, r *model.Tender) error {
+			assert.Equal(t, "Current Tender", current.Name)
+			restored = *r
+			return nil
+		})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, updatedTender)
 		assert.Equal(t, historyTender.Name, updatedTender.Name)
-		assert.Equal(t, historyTender.Version, updatedTender.Version)
-
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("there were unfulfilled expectations: %s", err)
-		}
+		assert.Equal(t, 4, updatedTender.Version)
+		assert.Equal(t, model.TenderStatus("Published"), updatedTender.Status)
+		assert.Equal(t, model.Decimal("1000.00"), *updatedTender.BudgetMin)
+		assert.Nil(t, updatedTender.BudgetMax)
+		// Статус текущей версии сохраняется, поля берутся из восстановленной
+		assert.Equal(t, historyTender.Name, restored.Name)
+		assert.Equal(t, model.TenderStatus("Published"), restored.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("begin_transaction_error", func(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
 
-		tenderID := "test-tender-id"
-		version := 1
 		ctx := context.Background()
 
 		mock.ExpectBegin().WillReturnError(errors.New("begin transaction error"))
 
-		_, err := repo.RollbackTenderVersion(ctx, tenderID, version)
-		assert.Error(t, err)
+		_, err := repo.RollbackTenderVersion(ctx, tenderID, version, noCheck)
 		assert.EqualError(t, err, "failed to begin transaction: begin transaction error")
-
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("there were unfulfilled expectations: %s", err)
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("no_rows_error", func(t *testing.T) {
 		db, mock, repo := setupTest(t)
 		defer db.Close()
 
-		tenderID := "test-tender-id"
-		version := 1
 		ctx := context.Background()
 
 		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(tenderID, version).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.RollbackTenderVersion(ctx, tenderID, version, noCheck)
+		assert.ErrorIs(t, err, model.ErrVersionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
-		mock.ExpectQuery(regexp.QuoteMeta(`
-			SELECT tender_id, name, description, service_type, organization_id, creator_username, status, version, created_at, updated_at
-			FROM tender_history
-			WHERE tender_id = $1 AND version = $2
-		`)).WithArgs(tenderID, version).
+	t.Run("tender_not_found", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(tenderID, version).WillReturnRows(historyRow())
+		mock.ExpectQuery(lockQuery).WithArgs(tenderID).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.RollbackTenderVersion(ctx, tenderID, version, noCheck)
+		assert.ErrorIs(t, err, model.ErrTenderNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("check_failed", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(tenderID, version).WillReturnRows(historyRow())
+		mock.ExpectQuery(lockQuery).WithArgs(tenderID).WillReturnRows(currentRow())
+		mock.ExpectRollback()
+
+		_, err := repo.RollbackTenderVersion(ctx, tenderID, version, func(*model.Tender, *model.Tender) error {
+			return model.ErrInvalidAuction
+		})
+		assert.ErrorIs(t, err, model.ErrInvalidAuction)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("version_conflict", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(tenderID, version).WillReturnRows(historyRow())
+		mock.ExpectQuery(lockQuery).WithArgs(tenderID).WillReturnRows(currentRow())
+		mock.ExpectQuery(snapshotQuery).WithArgs(sqlmock.AnyArg(), tenderID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+		mock.ExpectPrepare(updateQuery).ExpectQuery().
+			WithArgs(tenderID, historyTender.Name, historyTender.Description, historyTender.ServiceType, "1000.00", nil, "RUB", nil, 4, sqlmock.AnyArg(), 3).
 			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.RollbackTenderVersion(ctx, tenderID, version, noCheck)
+		assert.ErrorIs(t, err, model.ErrVersionConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTenderVersions(t *testing.T) {
+	getTenderVersionsQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, service_type::text, organization_id, creator_username, status::text, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1
+		UNION ALL
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender_history
+		WHERE tender_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tenderID := "test-tender-id"
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+		}).
+			AddRow(tenderID, "Tender v2", "Description", "Construction", "org-id", "user1", "Published", nil, nil, nil, nil, false, nil, 2, time.Now(), time.Now()).
+			AddRow(tenderID, "Tender v1", "Description", "Construction", "org-id", "user1", "Created", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now())
+
+		mock.ExpectPrepare(getTenderVersionsQuery).ExpectQuery().WithArgs(tenderID, 5, 0).WillReturnRows(rows)
+
+		tenders, err := repo.GetTenderVersions(ctx, tenderID, 5, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, tenders, 2)
+		assert.Equal(t, 2, tenders[0].Version)
+		assert.Equal(t, "Tender v1", tenders[1].Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query_error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tenderID := "test-tender-id"
+		ctx := context.Background()
+
+		mock.ExpectPrepare(getTenderVersionsQuery).ExpectQuery().WithArgs(tenderID, 5, 0).WillReturnError(errors.New("query error"))
+
+		tenders, err := repo.GetTenderVersions(ctx, tenderID, 5, 0)
+
+		assert.EqualError(t, err, "failed to execute query for getting tender versions: query error")
+		assert.Nil(t, tenders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTenderVersion(t *testing.T) {
+	getTenderVersionQuery := regexp.QuoteMeta(`
+		SELECT id, name, description, service_type::text, organization_id, creator_username, status::text, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE id = $1 AND version = $2
+		UNION ALL
+		SELECT tender_id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender_history
+		WHERE tender_id = $1 AND version = $2
+		LIMIT 1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tenderID := "test-tender-id"
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+		}).AddRow(tenderID, "Tender v1", "Description", "Construction", "org-id", "user1", "Created", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now())
+
+		mock.ExpectPrepare(getTenderVersionQuery).ExpectQuery().WithArgs(tenderID, 1).WillReturnRows(rows)
+
+		tender, err := repo.GetTenderVersion(ctx, tenderID, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tender v1", tender.Name)
+		assert.Equal(t, 1, tender.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		tenderID := "test-tender-id"
+		ctx := context.Background()
+
+		mock.ExpectPrepare(getTenderVersionQuery).ExpectQuery().WithArgs(tenderID, 3).WillReturnError(sql.ErrNoRows)
+
+		tender, err := repo.GetTenderVersion(ctx, tenderID, 3)
 
+		assert.ErrorIs(t, err, model.ErrVersionNotFound)
+		assert.Nil(t, tender)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSearchTenders(t *testing.T) {
+	searchTendersQuery := regexp.QuoteMeta(`
+		SELECT t.id, t.name, t.description, t.service_type, t.organization_id, t.creator_username, t.status, t.budget_min, t.budget_max, t.budget_currency, t.deadline, t.auction, t.auction_ends_at, t.version, t.created_at, t.updated_at,
+			ts_rank(t.search_vector, q.query) AS rank,
+			ts_headline('russian', t.name || ' ' || coalesce(t.description, ''), q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM tender t
+		CROSS JOIN (SELECT websearch_to_tsquery('russian', $10) || websearch_to_tsquery('english', $10) AS query) q
+		WHERE t.search_vector @@ q.query
+		AND (cardinality($1::text[]) = 0 OR t.service_type::text = ANY($1))
+		AND (cardinality($2::text[]) = 0 OR t.status::text = ANY($2))
+		AND ($3::varchar IS NULL OR t.organization_id = $3)
+		AND ($4::varchar IS NULL OR t.creator_username = $4)
+		AND ($5::timestamptz IS NULL OR t.created_at >= $5)
+		AND ($6::timestamptz IS NULL OR t.created_at <= $6)
+		AND ($7::timestamptz IS NULL OR t.updated_at >= $7)
+		AND ($8::timestamptz IS NULL OR t.updated_at <= $8)
+		AND (
+			t.status = 'Published'
+			OR EXISTS (
+				SELECT 1
+				FROM organization_responsible orr
+				JOIN employee e ON e.id = orr.user_id
+				WHERE orr.organization_id = t.organization_id AND e.username = $9
+			)
+		)
+		ORDER BY rank DESC, t.id
+		LIMIT $11 OFFSET $12
+	`)
+	filter := model.TenderFilter{ServiceTypes: []model.TenderServiceType{model.TenderServiceTypeConstruction}}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectPrepare(searchTendersQuery).ExpectQuery().
+			WithArgs(pq.Array([]string{"Construction"}), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, "testuser", "строительство", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at", "rank", "snippet",
+			}).AddRow(
+				"1", "Строительство моста", "Описание", "Construction", "org-id", "testuser", "Published", nil, nil, nil, nil, false, nil, 1, time.Now(), time.Now(), 0.6, "<b>Строительство</b> моста",
+			))
+
+		results, err := repo.SearchTenders(ctx, "строительство", 10, 0, filter, "testuser")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Строительство моста", results[0].Name)
+		assert.Equal(t, 0.6, results[0].Rank)
+		assert.Equal(t, "<b>Строительство</b> моста", results[0].Snippet)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query_error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectPrepare(searchTendersQuery).ExpectQuery().
+			WithArgs(pq.Array([]string{"Construction"}), pq.Array([]string{}), nil, nil, nil, nil, nil, nil, "testuser", "строительство", 10, 0).
+			WillReturnError(errors.New("query error"))
+
+		results, err := repo.SearchTenders(ctx, "строительство", 10, 0, filter, "testuser")
+
+		assert.EqualError(t, err, "failed to execute query for searching tenders: query error")
+		assert.Nil(t, results)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetExpiredTenders(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, name, description, service_type, organization_id, creator_username, status, budget_min, budget_max, budget_currency, deadline, auction, auction_ends_at, version, created_at, updated_at
+		FROM tender
+		WHERE status = 'Published' AND deadline <= $1
+		ORDER BY deadline, id
+		LIMIT $2
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+		deadline := now.Add(-time.Hour)
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(now, 100).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "description", "service_type", "organization_id", "creator_username", "status", "budget_min", "budget_max", "budget_currency", "deadline", "auction", "auction_ends_at", "version", "created_at", "updated_at",
+			}).AddRow(
+				"1", "Test Tender", "Description", "Construction", "org-id", "user1", "Published", nil, nil, nil, deadline, false, nil, 2, time.Now(), time.Now(),
+			))
+
+		tenders, err := repo.GetExpiredTenders(ctx, now, 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, tenders, 1)
+		assert.WithinDuration(t, deadline, *tenders[0].Deadline, time.Second)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query_error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(now, 100).WillReturnError(errors.New("query error"))
+
+		tenders, err := repo.GetExpiredTenders(ctx, now, 100)
+
+		assert.Error(t, err)
+		assert.Nil(t, tenders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSetTenderCriteria(t *testing.T) {
+	deleteQuery := regexp.QuoteMeta(`
+		DELETE FROM tender_criterion
+		WHERE tender_id = $1 AND NOT (name = ANY($2))
+	`)
+	upsertQuery := regexp.QuoteMeta(`
+		INSERT INTO tender_criterion (id, tender_id, name, weight, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tender_id, name) DO UPDATE
+		SET weight = EXCLUDED.weight
+		RETURNING id, tender_id, name, weight, created_at
+	`)
+	tenderID := uuid.New().String()
+	now := time.Now()
+	criteria := []model.TenderCriterion{
+		{ID: "criterion-1", TenderID: tenderID, Name: "price", Weight: 60, CreatedAt: now},
+		{ID: "criterion-2", TenderID: tenderID, Name: "experience", Weight: 40, CreatedAt: now},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(tenderID, pq.Array([]string{"price", "experience"})).WillReturnResult(sqlmock.NewResult(0, 1))
+		prep := mock.ExpectPrepare(upsertQuery)
+		// Критерий price уже был у тендера - возвращается его прежний id
+		prep.ExpectQuery().WithArgs("criterion-1", tenderID, "price", 60, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tender_id", "name", "weight", "created_at"}).AddRow("existing-price", tenderID, "price", 60, now))
+		prep.ExpectQuery().WithArgs("criterion-2", tenderID, "experience", 40, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tender_id", "name", "weight", "created_at"}).AddRow("criterion-2", tenderID, "experience", 40, now))
+		mock.ExpectCommit()
+
+		saved, err := repo.SetTenderCriteria(context.Background(), tenderID, criteria)
+		assert.NoError(t, err)
+		assert.Len(t, saved, 2)
+		assert.Equal(t, "existing-price", saved[0].ID)
+		assert.Equal(t, 40, saved[1].Weight)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WillReturnError(sql.ErrConnDone)
 		mock.ExpectRollback()
 
-		_, err := repo.RollbackTenderVersion(ctx, tenderID, version)
+		saved, err := repo.SetTenderCriteria(context.Background(), tenderID, criteria)
 		assert.Error(t, err)
-		assert.EqualError(t, err, model.ErrVersionNotFound.Error())
+		assert.Nil(t, saved)
 
-		if err := mock.ExpectationsWereMet(); err != nil {
-			t.Errorf("there were unfulfilled expectations: %s", err)
-		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("upsert error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare(upsertQuery).ExpectQuery().WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		saved, err := repo.SetTenderCriteria(context.Background(), tenderID, criteria)
+		assert.Error(t, err)
+		assert.Nil(t, saved)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTenderCriteria(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, tender_id, name, weight, created_at
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY name
+	`)
+	tenderID := uuid.New().String()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID).WillReturnRows(sqlmock.NewRows([]string{"id", "tender_id", "name", "weight", "created_at"}).
+			AddRow("criterion-2", tenderID, "experience", 40, time.Now()).
+			AddRow("criterion-1", tenderID, "price", 60, time.Now()))
+
+		criteria, err := repo.GetTenderCriteria(context.Background(), tenderID)
+		assert.NoError(t, err)
+		assert.Len(t, criteria, 2)
+		assert.Equal(t, "experience", criteria[0].Name)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(tenderID).WillReturnError(sql.ErrConnDone)
+
+		criteria, err := repo.GetTenderCriteria(context.Background(), tenderID)
+		assert.Error(t, err)
+		assert.Nil(t, criteria)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAuction(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT t.id, t.auction_ends_at, w.price, w.currency,
+			(SELECT COUNT(*) FROM bid b WHERE b.tender_id = t.id AND b.status = 'Published' AND b.price IS NOT NULL AND b.currency = t.budget_currency),
+			ar.bid_id, ar.price, ar.currency, ar.finished_at
+		FROM tender t
+		LEFT JOIN LATERAL (
+			SELECT price, currency
+			FROM bid
+			WHERE tender_id = t.id AND status = 'Published' AND price IS NOT NULL AND currency = t.budget_currency
+			ORDER BY price, updated_at, id
+			LIMIT 1
+		) w ON true
+		LEFT JOIN auction_result ar ON ar.tender_id = t.id
+		WHERE t.id = $1 AND t.auction
+	`)
+	columns := []string{"id", "auction_ends_at", "price", "currency", "count", "bid_id", "price", "currency", "finished_at"}
+	endsAt := time.Now().Add(time.Hour)
+
+	t.Run("running", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1").WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("tender-1", endsAt, []byte("1400.00"), "RUB", 3, nil, nil, nil, nil))
+
+		auction, err := repo.GetAuction(context.Background(), "tender-1")
+		assert.NoError(t, err)
+		assert.Equal(t, model.Decimal("1400.00"), *auction.LowestPrice)
+		assert.Equal(t, 3, auction.BidsCount)
+		assert.Nil(t, auction.Result)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("finished", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		finishedAt := endsAt.Add(time.Minute)
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1").WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("tender-1", endsAt, []byte("1400.00"), "RUB", 3, "bid-1", []byte("1400.00"), "RUB", finishedAt))
+
+		auction, err := repo.GetAuction(context.Background(), "tender-1")
+		assert.NoError(t, err)
+		assert.NotNil(t, auction.Result)
+		assert.Equal(t, "bid-1", *auction.Result.BidID)
+		assert.Equal(t, "tender-1", auction.Result.TenderID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not_auction", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1").WillReturnError(sql.ErrNoRows)
+
+		auction, err := repo.GetAuction(context.Background(), "tender-1")
+		assert.ErrorIs(t, err, model.ErrNotAuction)
+		assert.Nil(t, auction)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFinishAuctions(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO auction_result (tender_id, bid_id, price, currency, finished_at)
+		SELECT t.id, w.id, w.price, w.currency, $1
+		FROM tender t
+		LEFT JOIN LATERAL (
+			SELECT id, price, currency
+			FROM bid
+			WHERE tender_id = t.id AND status = 'Published' AND price IS NOT NULL AND currency = t.budget_currency
+			ORDER BY price, updated_at, id
+			LIMIT 1
+		) w ON true
+		WHERE t.auction AND t.status = 'Published' AND t.auction_ends_at <= $1
+			AND NOT EXISTS (SELECT 1 FROM auction_result ar WHERE ar.tender_id = t.id)
+		ON CONFLICT (tender_id) DO NOTHING
+		RETURNING tender_id, bid_id, price, currency, finished_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"tender_id", "bid_id", "price", "currency", "finished_at"}).
+			AddRow("tender-1", "bid-1", []byte("1400.00"), "RUB", now).
+			AddRow("tender-2", nil, nil, nil, now))
+
+		results, err := repo.FinishAuctions(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "bid-1", *results[0].BidID)
+		assert.Nil(t, results[1].BidID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTest(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(now).WillReturnError(sql.ErrConnDone)
+
+		results, err := repo.FinishAuctions(context.Background(), now)
+		assert.Error(t, err)
+		assert.Nil(t, results)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -5,8 +5,12 @@ import (
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type userRepository struct {
@@ -24,7 +28,7 @@ func NewUserRepository(db *sql.DB, logger *slog.Logger) repository.UserRepositor
 func (r *userRepository) GetUserById(ctx context.Context, id string) (*model.User, error) {
 
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, username, first_name, last_name, created_at, updated_at
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
 		FROM employee  
 		WHERE id = $1
 	`)
@@ -39,6 +43,9 @@ func (r *userRepository) GetUserById(ctx context.Context, id string) (*model.Use
 		&user.Username,
 		&user.First_name,
 		&user.Last_name,
+		&user.Email,
+		&user.Language,
+		&user.EmailNotifications,
 		&user.Created_at,
 		&user.Updated_at,
 	)
@@ -47,7 +54,7 @@ func (r *userRepository) GetUserById(ctx context.Context, id string) (*model.Use
 			r.logger.ErrorContext(ctx, "Error getting user by id", slog.Any("error", err))
 			return nil, fmt.Errorf("failed to execute query for getting user by id: %w", err)
 		}
-		return nil, err
+		return nil, model.ErrUserNotFound
 	}
 
 	return &user, nil
@@ -56,7 +63,7 @@ func (r *userRepository) GetUserById(ctx context.Context, id string) (*model.Use
 func (r *userRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, username, first_name, last_name, created_at, updated_at
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
 		FROM employee  
 		WHERE username = $1
 	`)
@@ -71,6 +78,9 @@ func (r *userRepository) GetUserByUsername(ctx context.Context, username string)
 		&user.Username,
 		&user.First_name,
 		&user.Last_name,
+		&user.Email,
+		&user.Language,
+		&user.EmailNotifications,
 		&user.Created_at,
 		&user.Updated_at,
 	)
@@ -79,12 +89,38 @@ func (r *userRepository) GetUserByUsername(ctx context.Context, username string)
 			r.logger.ErrorContext(ctx, "Error getting user by username", slog.Any("error", err))
 			return nil, fmt.Errorf("failed to execute query for getting user by username: %w", err)
 		}
-		return nil, err
+		return nil, model.ErrUserNotFound
 	}
 
 	return &user, nil
 }
 
+// GetUserPasswordHash возвращает хеш пароля; пустая строка - пароль не задан
+func (r *userRepository) GetUserPasswordHash(ctx context.Context, username string) (string, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT COALESCE(password_hash, '')
+		FROM employee
+		WHERE username = $1
+	`)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare statement for getting user password: %w", err)
+	}
+	defer stmt.Close()
+
+	var hash string
+	err = stmt.QueryRowContext(ctx, username).Scan(&hash)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.ErrorContext(ctx, "Error getting user password", slog.Any("error", err))
+			return "", fmt.Errorf("failed to execute query for getting user password: %w", err)
+		}
+		return "", model.ErrUserNotFound
+	}
+
+	return hash, nil
+}
+
 func (r *userRepository) GetOrganizationByUsername(ctx context.Context, username string) (*model.Organization, error) {
 
 	stmt, err := r.db.PrepareContext(ctx, `
@@ -114,3 +150,160 @@ func (r *userRepository) GetOrganizationByUsername(ctx context.Context, username
 
 	return &organization, nil
 }
+
+func (r *userRepository) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO employee (id, username, first_name, last_name, email, language, email_notifications, password_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+		RETURNING id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for creating user: %w", err)
+	}
+	defer stmt.Close()
+
+	createdUser := model.User{}
+	err = stmt.QueryRowContext(ctx, user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, user.PasswordHash).Scan(
+		&createdUser.Id,
+		&createdUser.Username,
+		&createdUser.First_name,
+		&createdUser.Last_name,
+		&createdUser.Email,
+		&createdUser.Language,
+		&createdUser.EmailNotifications,
+		&createdUser.Created_at,
+		&createdUser.Updated_at,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+			return nil, model.ErrUserExists
+		}
+		r.logger.ErrorContext(ctx, "Error creating user", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for creating user: %w", err)
+	}
+
+	return &createdUser, nil
+}
+
+func (r *userRepository) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE employee
+		SET first_name = $2, last_name = $3, email = $4, language = $5, email_notifications = $6, updated_at = $7,
+			password_hash = COALESCE(NULLIF($8, ''), password_hash)
+		WHERE id = $1
+		RETURNING id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for updating user: %w", err)
+	}
+	defer stmt.Close()
+
+	updatedUser := model.User{}
+	err = stmt.QueryRowContext(ctx, user.Id, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, time.Now(), user.PasswordHash).Scan(
+		&updatedUser.Id,
+		&updatedUser.Username,
+		&updatedUser.First_name,
+		&updatedUser.Last_name,
+		&updatedUser.Email,
+		&updatedUser.Language,
+		&updatedUser.EmailNotifications,
+		&updatedUser.Created_at,
+		&updatedUser.Updated_at,
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.ErrorContext(ctx, "Error updating user", slog.Any("error", err))
+			return nil, fmt.Errorf("failed to execute query for updating user: %w", err)
+		}
+		return nil, model.ErrUserNotFound
+	}
+
+	return &updatedUser, nil
+}
+
+func (r *userRepository) GetUsers(ctx context.Context, limit int, offset int) ([]model.User, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+		FROM employee
+		ORDER BY username
+		LIMIT $1 OFFSET $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting users: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting users", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		user := model.User{}
+		if err := rows.Scan(
+			&user.Id,
+			&user.Username,
+			&user.First_name,
+			&user.Last_name,
+			&user.Email,
+			&user.Language,
+			&user.EmailNotifications,
+			&user.Created_at,
+			&user.Updated_at,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) GetResponsibleOrganizations(ctx context.Context, username string, limit int, offset int) ([]model.Organization, error) {
+
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at
+		FROM organization o
+		JOIN organization_responsible orr ON orr.organization_id = o.id
+		JOIN employee e ON e.id = orr.user_id
+		WHERE e.username = $1
+		ORDER BY o.name, o.id
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting responsible organizations: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, username, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting responsible organizations", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting responsible organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var organizations []model.Organization
+	for rows.Next() {
+		organization := model.Organization{}
+		if err := rows.Scan(
+			&organization.Id,
+			&organization.Name,
+			&organization.Description,
+			&organization.Type,
+			&organization.Created_at,
+			&organization.Updated_at,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		organizations = append(organizations, organization)
+	}
+
+	return organizations, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,24 +35,28 @@ func TestGetUserById(t *testing.T) {
 
 	ctx := context.Background()
 	userID := uuid.New().String()
+	email := "test@example.com"
 	expectedUser := &model.User{
-		Id:         userID,
-		Username:   "testuser",
-		First_name: "Test",
-		Last_name:  "User",
-		Created_at: time.Now(),
-		Updated_at: time.Now(),
+		Id:                 userID,
+		Username:           "testuser",
+		First_name:         "Test",
+		Last_name:          "User",
+		Email:              &email,
+		Language:           model.LanguageEn,
+		EmailNotifications: true,
+		Created_at:         time.Now(),
+		Updated_at:         time.Now(),
 	}
 
 	query := regexp.QuoteMeta(`
-		SELECT id, username, first_name, last_name, created_at, updated_at
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
 		FROM employee  
 		WHERE id = $1
 	`)
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "created_at", "updated_at"}).
-			AddRow(expectedUser.Id, expectedUser.Username, expectedUser.First_name, expectedUser.Last_name, expectedUser.Created_at, expectedUser.Updated_at)
+		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "email", "language", "email_notifications", "created_at", "updated_at"}).
+			AddRow(expectedUser.Id, expectedUser.Username, expectedUser.First_name, expectedUser.Last_name, expectedUser.Email, expectedUser.Language, expectedUser.EmailNotifications, expectedUser.Created_at, expectedUser.Updated_at)
 
 		mock.ExpectPrepare(query).ExpectQuery().WithArgs(userID).WillReturnRows(rows)
 
@@ -67,7 +72,7 @@ func TestGetUserById(t *testing.T) {
 		user, err := repo.GetUserById(ctx, userID)
 		assert.Error(t, err)
 		assert.Nil(t, user)
-		assert.Equal(t, sql.ErrNoRows, err)
+		assert.Equal(t, model.ErrUserNotFound, err)
 	})
 
 	t.Run("prepare statement error", func(t *testing.T) {
@@ -94,24 +99,28 @@ func TestGetUserByUsername(t *testing.T) {
 
 	ctx := context.Background()
 	username := "testuser"
+	email := "test@example.com"
 	expectedUser := &model.User{
-		Id:         uuid.New().String(),
-		Username:   username,
-		First_name: "Test",
-		Last_name:  "User",
-		Created_at: time.Now(),
-		Updated_at: time.Now(),
+		Id:                 uuid.New().String(),
+		Username:           username,
+		First_name:         "Test",
+		Last_name:          "User",
+		Email:              &email,
+		Language:           model.LanguageEn,
+		EmailNotifications: true,
+		Created_at:         time.Now(),
+		Updated_at:         time.Now(),
 	}
 
 	query := regexp.QuoteMeta(`
-		SELECT id, username, first_name, last_name, created_at, updated_at
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
 		FROM employee  
 		WHERE username = $1
 	`)
 
 	t.Run("success", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "created_at", "updated_at"}).
-			AddRow(expectedUser.Id, expectedUser.Username, expectedUser.First_name, expectedUser.Last_name, expectedUser.Created_at, expectedUser.Updated_at)
+		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "email", "language", "email_notifications", "created_at", "updated_at"}).
+			AddRow(expectedUser.Id, expectedUser.Username, expectedUser.First_name, expectedUser.Last_name, expectedUser.Email, expectedUser.Language, expectedUser.EmailNotifications, expectedUser.Created_at, expectedUser.Updated_at)
 
 		mock.ExpectPrepare(query).ExpectQuery().WithArgs(username).WillReturnRows(rows)
 
@@ -127,7 +136,7 @@ func TestGetUserByUsername(t *testing.T) {
 		user, err := repo.GetUserByUsername(ctx, username)
 		assert.Error(t, err)
 		assert.Nil(t, user)
-		assert.Equal(t, sql.ErrNoRows, err)
+		assert.Equal(t, model.ErrUserNotFound, err)
 	})
 
 	t.Run("prepare statement error", func(t *testing.T) {
@@ -206,3 +215,207 @@ func TestGetOrganizationByUsername(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to execute query for getting organization by username")
 	})
 }
+
+func TestGetUserPasswordHash(t *testing.T) {
+	db, mock, repo := setupTestUser(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`
+		SELECT COALESCE(password_hash, '')
+		FROM employee
+		WHERE username = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("testuser").WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow("$2a$10$hash"))
+
+		hash, err := repo.GetUserPasswordHash(ctx, "testuser")
+		assert.NoError(t, err)
+		assert.Equal(t, "$2a$10$hash", hash)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+		hash, err := repo.GetUserPasswordHash(ctx, "unknown")
+		assert.Empty(t, hash)
+		assert.Equal(t, model.ErrUserNotFound, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateUser(t *testing.T) {
+	db, mock, repo := setupTestUser(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	user := &model.User{
+		Id:           uuid.New().String(),
+		Username:     "testuser",
+		First_name:   "Test",
+		Last_name:    "User",
+		PasswordHash: "$2a$10$hash",
+	}
+
+	query := regexp.QuoteMeta(`
+		INSERT INTO employee (id, username, first_name, last_name, email, language, email_notifications, password_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+		RETURNING id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "email", "language", "email_notifications", "created_at", "updated_at"}).
+			AddRow(user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, now, now)
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, user.PasswordHash).WillReturnRows(rows)
+
+		createdUser, err := repo.CreateUser(ctx, user)
+		assert.NoError(t, err)
+		assert.Equal(t, user.Username, createdUser.Username)
+		assert.Equal(t, now, createdUser.Created_at)
+	})
+
+	t.Run("user exists", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, user.PasswordHash).WillReturnError(&pq.Error{Code: uniqueViolationCode})
+
+		createdUser, err := repo.CreateUser(ctx, user)
+		assert.Nil(t, createdUser)
+		assert.Equal(t, model.ErrUserExists, err)
+	})
+
+	t.Run("query execution error", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, user.PasswordHash).WillReturnError(fmt.Errorf("query error"))
+
+		createdUser, err := repo.CreateUser(ctx, user)
+		assert.Nil(t, createdUser)
+		assert.Contains(t, err.Error(), "failed to execute query for creating user")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUser(t *testing.T) {
+	db, mock, repo := setupTestUser(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	user := &model.User{
+		Id:         uuid.New().String(),
+		Username:   "testuser",
+		First_name: "New",
+		Last_name:  "Name",
+	}
+
+	query := regexp.QuoteMeta(`
+		UPDATE employee
+		SET first_name = $2, last_name = $3, email = $4, language = $5, email_notifications = $6, updated_at = $7,
+			password_hash = COALESCE(NULLIF($8, ''), password_hash)
+		WHERE id = $1
+		RETURNING id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "email", "language", "email_notifications", "created_at", "updated_at"}).
+			AddRow(user.Id, user.Username, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, now, now)
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(user.Id, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, sqlmock.AnyArg(), user.PasswordHash).WillReturnRows(rows)
+
+		updatedUser, err := repo.UpdateUser(ctx, user)
+		assert.NoError(t, err)
+		assert.Equal(t, "New", updatedUser.First_name)
+		assert.Equal(t, "Name", updatedUser.Last_name)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(user.Id, user.First_name, user.Last_name, user.Email, user.Language, user.EmailNotifications, sqlmock.AnyArg(), user.PasswordHash).WillReturnError(sql.ErrNoRows)
+
+		updatedUser, err := repo.UpdateUser(ctx, user)
+		assert.Nil(t, updatedUser)
+		assert.Equal(t, model.ErrUserNotFound, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUsers(t *testing.T) {
+	db, mock, repo := setupTestUser(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	query := regexp.QuoteMeta(`
+		SELECT id, username, first_name, last_name, email, language, email_notifications, created_at, updated_at
+		FROM employee
+		ORDER BY username
+		LIMIT $1 OFFSET $2
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "username", "first_name", "last_name", "email", "language", "email_notifications", "created_at", "updated_at"}).
+			AddRow(uuid.New().String(), "alice", "Alice", "A", "alice@example.com", "ru", true, now, now).
+			AddRow(uuid.New().String(), "bob", "Bob", "B", nil, "en", false, now, now)
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(5, 0).WillReturnRows(rows)
+
+		users, err := repo.GetUsers(ctx, 5, 0)
+		assert.NoError(t, err)
+		assert.Len(t, users, 2)
+		assert.Equal(t, "alice", users[0].Username)
+	})
+
+	t.Run("query execution error", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(5, 0).WillReturnError(fmt.Errorf("query error"))
+
+		users, err := repo.GetUsers(ctx, 5, 0)
+		assert.Nil(t, users)
+		assert.Contains(t, err.Error(), "failed to execute query for getting users")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetResponsibleOrganizations(t *testing.T) {
+	db, mock, repo := setupTestUser(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	username := "testuser"
+
+	query := regexp.QuoteMeta(`
+		SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at
+		FROM organization o
+		JOIN organization_responsible orr ON orr.organization_id = o.id
+		JOIN employee e ON e.id = orr.user_id
+		WHERE e.username = $1
+		ORDER BY o.name, o.id
+		LIMIT $2 OFFSET $3
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "name", "description", "type", "created_at", "updated_at"}).
+			AddRow(uuid.New().String(), "Test Organization", "Description", "LLC", now, now)
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(username, 5, 0).WillReturnRows(rows)
+
+		organizations, err := repo.GetResponsibleOrganizations(ctx, username, 5, 0)
+		assert.NoError(t, err)
+		assert.Len(t, organizations, 1)
+		assert.Equal(t, "Test Organization", organizations[0].Name)
+	})
+
+	t.Run("query execution error", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(username, 5, 0).WillReturnError(fmt.Errorf("query error"))
+
+		organizations, err := repo.GetResponsibleOrganizations(ctx, username, 5, 0)
+		assert.Nil(t, organizations)
+		assert.Contains(t, err.Error(), "failed to execute query for getting responsible organizations")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,430 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type webhookRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewWebhookRepository(db *sql.DB, logger *slog.Logger) repository.WebhookRepository {
+	return &webhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// rowScanner общий для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row rowScanner, webhook *model.Webhook) error {
+	var eventTypes pq.StringArray
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.OrganizationID,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventTypes,
+		&webhook.CreatedBy,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	webhook.EventTypes = make([]model.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		webhook.EventTypes[i] = model.EventType(eventType)
+	}
+	return nil
+}
+
+func eventTypesArray(eventTypes []model.EventType) pq.StringArray {
+	array := make(pq.StringArray, len(eventTypes))
+	for i, eventType := range eventTypes {
+		array[i] = string(eventType)
+	}
+	return array
+}
+
+func scanWebhookDelivery(row rowScanner, delivery *model.WebhookDelivery, extra ...any) error {
+	dest := []any{
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+func (r *webhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO webhook (id, organization_id, url, secret, event_types, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for creating webhook: %w", err)
+	}
+	defer stmt.Close()
+
+	created := &model.Webhook{}
+	err = scanWebhook(stmt.QueryRowContext(ctx,
+		webhook.ID,
+		webhook.OrganizationID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypesArray(webhook.EventTypes),
+		webhook.CreatedBy,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	), created)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating webhook", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *webhookRepository) GetWebhookById(ctx context.Context, id string) (*model.Webhook, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+		FROM webhook
+		WHERE id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting webhook: %w", err)
+	}
+	defer stmt.Close()
+
+	webhook := &model.Webhook{}
+	if err := scanWebhook(stmt.QueryRowContext(ctx, id), webhook); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrWebhookNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error getting webhook", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookRepository) GetWebhooks(ctx context.Context, organizationID string) ([]model.Webhook, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+		FROM webhook
+		WHERE organization_id = $1
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting webhooks: %w", err)
+	}
+	defer stmt.Close()
+
+	return r.queryWebhooks(ctx, stmt, organizationID)
+}
+
+// GetSubscribedWebhooks возвращает подписки организации тендера на тип события
+func (r *webhookRepository) GetSubscribedWebhooks(ctx context.Context, tenderID string, eventType model.EventType) ([]model.Webhook, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT w.id, w.organization_id, w.url, w.secret, w.event_types, w.created_by, w.created_at, w.updated_at
+		FROM webhook w
+		JOIN tender t ON t.organization_id = w.organization_id
+		WHERE t.id = $1 AND $2 = ANY(w.event_types)
+		ORDER BY w.created_at, w.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting subscribed webhooks: %w", err)
+	}
+	defer stmt.Close()
+
+	return r.queryWebhooks(ctx, stmt, tenderID, eventType)
+}
+
+func (r *webhookRepository) queryWebhooks(ctx context.Context, stmt *sql.Stmt, args ...any) ([]model.Webhook, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting webhooks", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+	for rows.Next() {
+		webhook := model.Webhook{}
+		if err := scanWebhook(rows, &webhook); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE webhook
+		SET url = $2, secret = $3, event_types = $4, updated_at = $5
+		WHERE id = $1
+		RETURNING id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for updating webhook: %w", err)
+	}
+	defer stmt.Close()
+
+	updated := &model.Webhook{}
+	err = scanWebhook(stmt.QueryRowContext(ctx,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypesArray(webhook.EventTypes),
+		time.Now(),
+	), updated)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrWebhookNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error updating webhook", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		DELETE FROM webhook
+		WHERE id = $1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for deleting webhook: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting webhook", slog.Any("error", err))
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhookDelivery не дублирует доставку, если relay повторно публикует то же событие
+func (r *webhookRepository) CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for creating webhook delivery: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating webhook delivery", slog.Any("error", err))
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries берёт в работу доставки, время которых пришло, и откладывает их до leaseUntil.
+// Если отправка оборвётся, доставка вернётся в очередь после leaseUntil
+func (r *webhookRepository) ClaimWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.WebhookDispatch, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE webhook_delivery d
+		SET next_attempt_at = $2
+		FROM webhook w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = 'Pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at, w.url, w.secret
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for claiming webhook deliveries: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, now, leaseUntil, limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error claiming webhook deliveries", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var dispatches []model.WebhookDispatch
+	for rows.Next() {
+		dispatch := model.WebhookDispatch{}
+		if err := scanWebhookDelivery(rows, &dispatch.WebhookDelivery, &dispatch.URL, &dispatch.Secret); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		dispatches = append(dispatches, dispatch)
+	}
+
+	return dispatches, nil
+}
+
+func (r *webhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE webhook_delivery
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for updating webhook delivery: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating webhook delivery", slog.Any("error", err))
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return model.ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int, offset int) ([]model.WebhookDelivery, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_delivery
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting webhook deliveries: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, webhookID, limit, offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error getting webhook deliveries", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to execute query for getting webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		delivery := model.WebhookDelivery{}
+		if err := scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) GetWebhookDeliveryById(ctx context.Context, id string) (*model.WebhookDelivery, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_delivery
+		WHERE id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for getting webhook delivery: %w", err)
+	}
+	defer stmt.Close()
+
+	delivery := &model.WebhookDelivery{}
+	if err := scanWebhookDelivery(stmt.QueryRowContext(ctx, id), delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error getting webhook delivery", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// RedeliverWebhookDelivery возвращает доставку в очередь с новым счётчиком попыток
+func (r *webhookRepository) RedeliverWebhookDelivery(ctx context.Context, id string, now time.Time) (*model.WebhookDelivery, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE webhook_delivery
+		SET status = 'Pending', attempts = 0, next_attempt_at = $2, delivered_at = NULL
+		WHERE id = $1
+		RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement for redelivering webhook delivery: %w", err)
+	}
+	defer stmt.Close()
+
+	delivery := &model.WebhookDelivery{}
+	if err := scanWebhookDelivery(stmt.QueryRowContext(ctx, id, now), delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error redelivering webhook delivery", slog.Any("error", err))
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
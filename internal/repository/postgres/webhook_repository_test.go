@@ -0,0 +1,335 @@
+package postgres
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestWebhook(t *testing.T) (*sql.DB, sqlmock.Sqlmock, repository.WebhookRepository) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	logger := slog.Default()
+	repo := NewWebhookRepository(db, logger)
+	return db, mock, repo
+}
+
+var (
+	webhookColumns  = []string{"id", "organization_id", "url", "secret", "event_types", "created_by", "created_at", "updated_at"}
+	deliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_status_code", "last_error", "created_at", "delivered_at"}
+)
+
+func TestCreateWebhook(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO webhook (id, organization_id, url, secret, event_types, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+	`)
+	now := time.Now()
+	webhook := &model.Webhook{
+		ID:             "webhook-1",
+		OrganizationID: "org-1",
+		URL:            "https://erp.example.com/hooks",
+		Secret:         "0123456789abcdef",
+		EventTypes:     []model.EventType{model.EventBidSubmitted, model.EventTenderClosed},
+		CreatedBy:      "ivanov",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().
+			WithArgs("webhook-1", "org-1", "https://erp.example.com/hooks", "0123456789abcdef", pq.StringArray{"BidSubmitted", "TenderClosed"}, "ivanov", now, now).
+			WillReturnRows(sqlmock.NewRows(webhookColumns).
+				AddRow("webhook-1", "org-1", "https://erp.example.com/hooks", "0123456789abcdef", []byte("{BidSubmitted,TenderClosed}"), "ivanov", now, now))
+
+		created, err := repo.CreateWebhook(context.Background(), webhook)
+		assert.NoError(t, err)
+		assert.Equal(t, "webhook-1", created.ID)
+		assert.Equal(t, []model.EventType{model.EventBidSubmitted, model.EventTenderClosed}, created.EventTypes)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(sql.ErrConnDone)
+
+		created, err := repo.CreateWebhook(context.Background(), webhook)
+		assert.Error(t, err)
+		assert.Nil(t, created)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetWebhookById(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, organization_id, url, secret, event_types, created_by, created_at, updated_at
+		FROM webhook
+		WHERE id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		now := time.Now()
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("webhook-1").
+			WillReturnRows(sqlmock.NewRows(webhookColumns).
+				AddRow("webhook-1", "org-1", "https://erp.example.com/hooks", "0123456789abcdef", []byte("{BidSubmitted}"), "ivanov", now, now))
+
+		webhook, err := repo.GetWebhookById(context.Background(), "webhook-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "org-1", webhook.OrganizationID)
+		assert.Equal(t, "0123456789abcdef", webhook.Secret)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("missing").WillReturnRows(sqlmock.NewRows(webhookColumns))
+
+		webhook, err := repo.GetWebhookById(context.Background(), "missing")
+		assert.ErrorIs(t, err, model.ErrWebhookNotFound)
+		assert.Nil(t, webhook)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetSubscribedWebhooks(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT w.id, w.organization_id, w.url, w.secret, w.event_types, w.created_by, w.created_at, w.updated_at
+		FROM webhook w
+		JOIN tender t ON t.organization_id = w.organization_id
+		WHERE t.id = $1 AND $2 = ANY(w.event_types)
+		ORDER BY w.created_at, w.id
+	`)
+
+	db, mock, repo := setupTestWebhook(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("tender-1", model.EventBidSubmitted).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).
+			AddRow("webhook-1", "org-1", "https://erp.example.com/hooks", "0123456789abcdef", []byte("{BidSubmitted}"), "ivanov", now, now).
+			AddRow("webhook-2", "org-1", "https://crm.example.com/hooks", "fedcba9876543210", []byte("{BidSubmitted,BidApproved}"), "petrov", now, now))
+
+	webhooks, err := repo.GetSubscribedWebhooks(context.Background(), "tender-1", model.EventBidSubmitted)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.Equal(t, "https://crm.example.com/hooks", webhooks[1].URL)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		DELETE FROM webhook
+		WHERE id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("webhook-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.DeleteWebhook(context.Background(), "webhook-1"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("missing").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.DeleteWebhook(context.Background(), "missing"), model.ErrWebhookNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateWebhookDelivery(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`)
+
+	db, mock, repo := setupTestWebhook(t)
+	defer db.Close()
+
+	now := time.Now()
+	delivery := &model.WebhookDelivery{
+		ID:            "delivery-1",
+		WebhookID:     "webhook-1",
+		EventID:       42,
+		EventType:     model.EventBidSubmitted,
+		Payload:       []byte(`{"id":42}`),
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	// Повторная публикация события попадает в ON CONFLICT и не считается ошибкой
+	mock.ExpectPrepare(query).ExpectExec().
+		WithArgs("delivery-1", "webhook-1", int64(42), model.EventBidSubmitted, []byte(`{"id":42}`), model.WebhookDeliveryPending, 0, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.CreateWebhookDelivery(context.Background(), delivery))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimWebhookDeliveries(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE webhook_delivery d
+		SET next_attempt_at = $2
+		FROM webhook w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = 'Pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at, w.url, w.secret
+	`)
+
+	db, mock, repo := setupTestWebhook(t)
+	defer db.Close()
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs(now, leaseUntil, 20).
+		WillReturnRows(sqlmock.NewRows(append(deliveryColumns, "url", "secret")).
+			AddRow("delivery-1", "webhook-1", int64(42), "BidSubmitted", []byte(`{"id":42}`), "Pending", 2, leaseUntil, 502, "unexpected response status 502", now, nil, "https://erp.example.com/hooks", "0123456789abcdef"))
+
+	dispatches, err := repo.ClaimWebhookDeliveries(context.Background(), now, leaseUntil, 20)
+	assert.NoError(t, err)
+	assert.Len(t, dispatches, 1)
+	assert.Equal(t, "delivery-1", dispatches[0].ID)
+	assert.Equal(t, 2, dispatches[0].Attempts)
+	assert.Equal(t, 502, *dispatches[0].LastStatusCode)
+	assert.Equal(t, "https://erp.example.com/hooks", dispatches[0].URL)
+	assert.Equal(t, "0123456789abcdef", dispatches[0].Secret)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWebhookDelivery(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE webhook_delivery
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1
+	`)
+
+	db, mock, repo := setupTestWebhook(t)
+	defer db.Close()
+
+	now := time.Now()
+	statusCode := 200
+	delivery := &model.WebhookDelivery{
+		ID:             "delivery-1",
+		Status:         model.WebhookDeliveryDelivered,
+		Attempts:       3,
+		NextAttemptAt:  now,
+		LastStatusCode: &statusCode,
+		DeliveredAt:    &now,
+	}
+
+	mock.ExpectPrepare(query).ExpectExec().
+		WithArgs("delivery-1", model.WebhookDeliveryDelivered, 3, now, &statusCode, nil, &now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.UpdateWebhookDelivery(context.Background(), delivery))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_delivery
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`)
+
+	db, mock, repo := setupTestWebhook(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("webhook-1", 10, 0).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).
+			AddRow("delivery-2", "webhook-1", int64(43), "BidApproved", []byte(`{}`), "Delivered", 1, now, 204, nil, now, now).
+			AddRow("delivery-1", "webhook-1", int64(42), "BidSubmitted", []byte(`{}`), "Failed", 8, now, nil, "connection refused", now, nil))
+
+	deliveries, err := repo.GetWebhookDeliveries(context.Background(), "webhook-1", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[0].Status)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+	assert.Equal(t, model.WebhookDeliveryFailed, deliveries[1].Status)
+	assert.Equal(t, "connection refused", *deliveries[1].LastError)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	query := regexp.QuoteMeta(`
+		UPDATE webhook_delivery
+		SET status = 'Pending', attempts = 0, next_attempt_at = $2, delivered_at = NULL
+		WHERE id = $1
+		RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at
+	`)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("delivery-1", now).
+			WillReturnRows(sqlmock.NewRows(deliveryColumns).
+				AddRow("delivery-1", "webhook-1", int64(42), "BidSubmitted", []byte(`{}`), "Pending", 0, now, 500, "unexpected response status 500", now, nil))
+
+		delivery, err := repo.RedeliverWebhookDelivery(context.Background(), "delivery-1", now)
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, repo := setupTestWebhook(t)
+		defer db.Close()
+
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs("missing", now).WillReturnRows(sqlmock.NewRows(deliveryColumns))
+
+		delivery, err := repo.RedeliverWebhookDelivery(context.Background(), "missing", now)
+		assert.ErrorIs(t, err, model.ErrDeliveryNotFound)
+		assert.Nil(t, delivery)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
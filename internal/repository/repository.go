@@ -3,36 +3,102 @@ package repository
 import (
 	"Backend-trainee-assignment-autumn-2024/internal/model"
 	"context"
+	"time"
 )
 
 type TenderRepository interface {
 	CreateTender(context.Context, *model.Tender) (*model.Tender, error)
-	GetTenders(context.Context, int, int, []model.TenderServiceType) ([]model.Tender, error)
+	GetTenders(context.Context, int, int, *model.Cursor, model.TenderFilter, string) ([]model.Tender, error)
 	GetTenderById(context.Context, string) (*model.Tender, error)
-	GetTenderByUsername(context.Context, int, int, string) ([]model.Tender, error)
+	GetTenderByUsername(context.Context, int, int, *model.Cursor, string) ([]model.Tender, error)
 	UpdateTender(context.Context, *model.Tender) (*model.Tender, error)
 	IsUserResponsibleForTender(context.Context, string, string) (bool, error)
-	RollbackTenderVersion(context.Context, string, int) (*model.Tender, error)
+	RollbackTenderVersion(context.Context, string, int, func(*model.Tender, *model.Tender) error) (*model.Tender, error)
+	GetTenderVersions(context.Context, string, int, int) ([]model.Tender, error)
+	GetTenderVersion(context.Context, string, int) (*model.Tender, error)
+	SearchTenders(context.Context, string, int, int, model.TenderFilter, string) ([]model.TenderSearchResult, error)
+	GetExpiredTenders(context.Context, time.Time, int) ([]model.Tender, error)
+	SetTenderCriteria(context.Context, string, []model.TenderCriterion) ([]model.TenderCriterion, error)
+	GetTenderCriteria(context.Context, string) ([]model.TenderCriterion, error)
+	GetAuction(context.Context, string) (*model.Auction, error)
+	FinishAuctions(context.Context, time.Time) ([]model.AuctionResult, error)
 }
 
 type OrganizationRepository interface {
 	GetOrganizationById(context.Context, string) (*model.Organization, error)
 	IsUserResponsibleForOrganization(context.Context, string, string) (bool, error)
+	CreateOrganization(context.Context, *model.Organization, string) (*model.Organization, error)
+	GetOrganizations(context.Context, int, int) ([]model.Organization, error)
+	UpdateOrganization(context.Context, *model.Organization) (*model.Organization, error)
+	AddOrganizationResponsible(context.Context, *model.OrganizationResponsible) (*model.OrganizationResponsible, error)
+	RemoveOrganizationResponsible(context.Context, string, string) error
 }
 
 type UserRepository interface {
 	GetUserById(context.Context, string) (*model.User, error)
 	GetUserByUsername(context.Context, string) (*model.User, error)
+	GetUserPasswordHash(context.Context, string) (string, error)
 	GetOrganizationByUsername(context.Context, string) (*model.Organization, error)
+	CreateUser(context.Context, *model.User) (*model.User, error)
+	UpdateUser(context.Context, *model.User) (*model.User, error)
+	GetUsers(context.Context, int, int) ([]model.User, error)
+	GetResponsibleOrganizations(context.Context, string, int, int) ([]model.Organization, error)
 }
 
 type BidRepository interface {
 	CreateBid(context.Context, *model.Bid) (*model.Bid, error)
 	GetBidById(context.Context, string) (*model.Bid, error)
-	GetBidByUsername(context.Context, int, int, string) ([]model.Bid, error)
-	GetTenderBids(context.Context, string, int, int, string) ([]model.Bid, error)
-	GetBidStatus(context.Context, string) (model.BidStatus, error)
+	GetBidByUsername(context.Context, int, int, *model.Cursor, string) ([]model.Bid, error)
+	GetTenderBids(context.Context, string, int, int, *model.Cursor, model.BidSort, string) ([]model.Bid, error)
 	UpdateBid(context.Context, *model.Bid) (*model.Bid, error)
-	RollbackBidVersion(context.Context, string, int) (*model.Bid, error)
-	AddBidFeedback(context.Context, string, string, string) (*model.Bid, error)
+	RollbackBidVersion(context.Context, string, int, func(*model.Bid) error) (*model.Bid, error)
+	CreateBidReview(context.Context, *model.BidReview) (*model.BidReview, error)
+	SubmitBidDecision(context.Context, *model.BidDecisionRecord, func(*model.Tender, *model.Bid) error) (*model.Bid, *model.Tender, error)
+	GetBidDecisions(context.Context, string) ([]model.BidDecisionRecord, error)
+	HasTenderBidByAuthor(context.Context, string, string) (bool, error)
+	GetBidReviews(context.Context, string, int, int) ([]model.BidReview, error)
+	GetBidVersions(context.Context, string, int, int) ([]model.Bid, error)
+	GetBidVersion(context.Context, string, int) (*model.Bid, error)
+	SearchBids(context.Context, string, string, int, int, string) ([]model.BidSearchResult, error)
+	SaveBidScores(context.Context, []model.BidScore) ([]model.BidScore, error)
+	GetBidScores(context.Context, string) ([]model.BidScore, error)
+	PlaceAuctionPrice(context.Context, *model.Bid, time.Time, time.Duration) (*model.Bid, error)
+}
+
+type AttachmentRepository interface {
+	CreateAttachment(context.Context, *model.Attachment) (*model.Attachment, error)
+	GetAttachmentById(context.Context, string) (*model.Attachment, error)
+	GetAttachments(context.Context, model.AttachmentOwnerType, string) ([]model.Attachment, error)
+	DeleteAttachment(context.Context, string) error
+}
+
+type OutboxRepository interface {
+	PublishPendingEvents(context.Context, int, func(context.Context, model.Event) error) (int, error)
+	GetTenderEvents(context.Context, string, int64, int) ([]model.Event, error)
+}
+
+type WebhookRepository interface {
+	CreateWebhook(context.Context, *model.Webhook) (*model.Webhook, error)
+	GetWebhookById(context.Context, string) (*model.Webhook, error)
+	GetWebhooks(context.Context, string) ([]model.Webhook, error)
+	GetSubscribedWebhooks(context.Context, string, model.EventType) ([]model.Webhook, error)
+	UpdateWebhook(context.Context, *model.Webhook) (*model.Webhook, error)
+	DeleteWebhook(context.Context, string) error
+	CreateWebhookDelivery(context.Context, *model.WebhookDelivery) error
+	ClaimWebhookDeliveries(context.Context, time.Time, time.Time, int) ([]model.WebhookDispatch, error)
+	UpdateWebhookDelivery(context.Context, *model.WebhookDelivery) error
+	GetWebhookDeliveries(context.Context, string, int, int) ([]model.WebhookDelivery, error)
+	GetWebhookDeliveryById(context.Context, string) (*model.WebhookDelivery, error)
+	RedeliverWebhookDelivery(context.Context, string, time.Time) (*model.WebhookDelivery, error)
+}
+
+type NotificationRepository interface {
+	CreateNotifications(context.Context, []model.Notification) error
+	ClaimNotificationEmails(context.Context, time.Time, time.Time, int) ([]model.NotificationEmailDispatch, error)
+	UpdateNotificationEmail(context.Context, *model.NotificationEmail) error
+	GetNotifications(context.Context, string, bool, int, int) ([]model.Notification, error)
+	CountUnreadNotifications(context.Context, string) (int, error)
+	MarkNotificationRead(context.Context, string, string, time.Time) (*model.Notification, error)
+	MarkAllNotificationsRead(context.Context, string, time.Time) (int, error)
+	GetTenderBidAuthors(context.Context, string) ([]string, error)
 }
@@ -7,12 +7,22 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRouter(tenderHandler handler.TenderHandler, pingHandler handler.PingHandler, bidHandler handler.BidHandler) *fiber.App {
-	app := fiber.New()
+func SetupRouter(tenderHandler handler.TenderHandler, pingHandler handler.PingHandler, bidHandler handler.BidHandler, authHandler handler.AuthHandler, organizationHandler handler.OrganizationHandler, userHandler handler.UserHandler, attachmentHandler handler.AttachmentHandler, webhookHandler handler.WebhookHandler, eventHandler handler.EventHandler, notificationHandler handler.NotificationHandler, jwtSecret []byte, uploadLimit int) *fiber.App {
+	// Тело больше стандартного лимита не буферизуется сервером, размер проверяет BodyLimit маршрута
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true})
+	auth := middleware.AuthMiddleware(jwtSecret)
+
+	// Загрузка вложений регистрируется первой: остальным маршрутам достаётся стандартный лимит.
+	// Повышенный лимит действует только после авторизации, тело анонимного запроса не читается
+	app.Post("/tenders/:tenderId/attachments", auth, middleware.BodyLimit(uploadLimit), attachmentHandler.UploadTenderAttachment)
+	app.Post("/bids/:bidId/attachments", auth, middleware.BodyLimit(uploadLimit), attachmentHandler.UploadBidAttachment)
+	app.Use(middleware.BodyLimit(fiber.DefaultBodyLimit))
 
 	app.Get("/api/ping", pingHandler.Ping)
+	app.Post("/auth/token", authHandler.CreateToken)
+	app.Post("/employees/new", userHandler.CreateUser)
 
-	api := app.Group("/", middleware.AuthMiddleware) // Имитация авторизации
+	api := app.Group("/", auth)
 
 	api.Get("/tenders", tenderHandler.GetTenders)
 	api.Post("/tenders/new", tenderHandler.CreateTender)
@@ -21,16 +31,61 @@ func SetupRouter(tenderHandler handler.TenderHandler, pingHandler handler.PingHa
 	api.Put("/tenders/:tenderId/status", tenderHandler.UpdateTenderStatus)
 	api.Patch("/tenders/:tenderId", tenderHandler.EditTender)
 	api.Put("/tenders/:tenderId/rollback/:version", tenderHandler.RollbackTender)
+	api.Get("/tenders/:tenderId/versions", tenderHandler.GetTenderVersions)
+	api.Get("/tenders/:tenderId/versions/:version", tenderHandler.GetTenderVersion)
+	api.Get("/tenders/:tenderId/diff", tenderHandler.GetTenderDiff)
+	api.Put("/tenders/:tenderId/criteria", tenderHandler.SetTenderCriteria)
+	api.Get("/tenders/:tenderId/criteria", tenderHandler.GetTenderCriteria)
+	api.Get("/tenders/:tenderId/auction", tenderHandler.GetAuction)
+	api.Get("/tenders/:tenderId/events", eventHandler.StreamTenderEvents)
+	api.Get("/tenders/:tenderId/attachments", attachmentHandler.GetTenderAttachments)
+	api.Get("/tenders/:tenderId/attachments/:attachmentId", attachmentHandler.DownloadTenderAttachment)
+	api.Delete("/tenders/:tenderId/attachments/:attachmentId", attachmentHandler.DeleteTenderAttachment)
 
 	api.Post("/bids/new", bidHandler.CreateBid)
 	api.Get("/bids/my", bidHandler.GetCurrentUserBids)
+	api.Get("/bids/search", bidHandler.SearchBids)
 	api.Get("/bids/:tenderId/list", bidHandler.GetTenderBids)
 	api.Get("/bids/:bidId/status", bidHandler.GetBidStatus)
 	api.Put("/bids/:bidId/status", bidHandler.UpdateBidStatus)
 	api.Patch("/bids/:bidId/edit", bidHandler.EditBid)
 	api.Put("/bids/:bidId/submit_decision", bidHandler.SubmitBidDecision)
+	api.Get("/bids/:bidId/decisions", bidHandler.GetBidDecisions)
 	api.Put("/bids/:bidId/rollback/:version", bidHandler.RollbackBidVersion)
+	api.Get("/bids/:bidId/versions", bidHandler.GetBidVersions)
+	api.Get("/bids/:bidId/versions/:version", bidHandler.GetBidVersion)
+	api.Get("/bids/:bidId/diff", bidHandler.GetBidDiff)
+	api.Put("/bids/:bidId/scores", bidHandler.SubmitBidScores)
+	api.Get("/bids/:bidId/scores", bidHandler.GetBidScores)
+	api.Put("/bids/:bidId/auction_price", bidHandler.PlaceAuctionPrice)
+	api.Get("/bids/:bidId/attachments", attachmentHandler.GetBidAttachments)
+	api.Get("/bids/:bidId/attachments/:attachmentId", attachmentHandler.DownloadBidAttachment)
+	api.Delete("/bids/:bidId/attachments/:attachmentId", attachmentHandler.DeleteBidAttachment)
 	api.Put("bids/:bidId/feedback", bidHandler.AddBidFeedback)
+	api.Get("/bids/:tenderId/reviews", bidHandler.GetBidReviews)
+
+	api.Get("/organizations", organizationHandler.GetOrganizations)
+	api.Post("/organizations/new", organizationHandler.CreateOrganization)
+	api.Get("/organizations/my", userHandler.GetCurrentUserOrganizations)
+	api.Get("/organizations/:organizationId", organizationHandler.GetOrganization)
+	api.Patch("/organizations/:organizationId", organizationHandler.EditOrganization)
+	api.Put("/organizations/:organizationId/responsibles/:responsibleUsername", organizationHandler.AddOrganizationResponsible)
+	api.Delete("/organizations/:organizationId/responsibles/:responsibleUsername", organizationHandler.RemoveOrganizationResponsible)
+	api.Post("/organizations/:organizationId/webhooks", webhookHandler.CreateWebhook)
+	api.Get("/organizations/:organizationId/webhooks", webhookHandler.GetWebhooks)
+	api.Patch("/organizations/:organizationId/webhooks/:webhookId", webhookHandler.EditWebhook)
+	api.Delete("/organizations/:organizationId/webhooks/:webhookId", webhookHandler.DeleteWebhook)
+	api.Get("/organizations/:organizationId/webhooks/:webhookId/deliveries", webhookHandler.GetWebhookDeliveries)
+	api.Post("/organizations/:organizationId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhook)
+
+	api.Get("/notifications", notificationHandler.GetNotifications)
+	api.Get("/notifications/unread", notificationHandler.GetUnreadNotifications)
+	api.Put("/notifications/read", notificationHandler.MarkAllNotificationsRead)
+	api.Put("/notifications/:notificationId/read", notificationHandler.MarkNotificationRead)
+
+	api.Get("/employees", userHandler.GetUsers)
+	api.Get("/employees/:username", userHandler.GetUser)
+	api.Patch("/employees/:username", userHandler.EditUser)
 
 	return app
 }
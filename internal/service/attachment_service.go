@@ -0,0 +1,256 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"Backend-trainee-assignment-autumn-2024/internal/storage"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, username string, upload model.AttachmentUpload) (*model.Attachment, error)
+	GetAttachments(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, username string) ([]model.Attachment, error)
+	DownloadAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, attachmentID string, username string) (*model.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, attachmentID string, username string) error
+}
+
+type attachmentService struct {
+	attachmentRepository repository.AttachmentRepository
+	tenderRepository     repository.TenderRepository
+	bidRepository        repository.BidRepository
+	userRepository       repository.UserRepository
+	storage              storage.FileStorage
+	maxSize              int64
+	allowedTypes         []string
+	logger               *slog.Logger
+}
+
+func NewAttachmentService(attachmentRepository repository.AttachmentRepository, tenderRepository repository.TenderRepository, bidRepository repository.BidRepository, userRepository repository.UserRepository, storage storage.FileStorage, maxSize int64, allowedTypes []string, logger *slog.Logger) AttachmentService {
+	return &attachmentService{attachmentRepository, tenderRepository, bidRepository, userRepository, storage, maxSize, allowedTypes, logger}
+}
+
+// byteCounter считает записанные байты, чтобы не доверять размеру из заголовка запроса
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+func (s *attachmentService) UploadAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, username string, upload model.AttachmentUpload) (*model.Attachment, error) {
+	if err := s.checkAccess(ctx, ownerType, ownerID, username, true); err != nil {
+		return nil, err
+	}
+
+	if upload.Size > s.maxSize {
+		s.logger.ErrorContext(ctx, "Attachment is too large", slog.Int64("size", upload.Size))
+		return nil, model.ErrAttachmentTooLarge
+	}
+
+	// Тип определяется по содержимому, заявленному клиентом типу не доверяем
+	content := bufio.NewReader(upload.Content)
+	head, err := content.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("Error reading attachment: %w", err)
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !slices.Contains(s.allowedTypes, contentType) {
+		s.logger.ErrorContext(ctx, "Attachment type is not allowed", slog.String("contentType", contentType))
+		return nil, model.ErrAttachmentType
+	}
+
+	attachment := &model.Attachment{
+		ID:          uuid.NewString(),
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		FileName:    upload.FileName,
+		ContentType: contentType,
+		CreatedBy:   username,
+		CreatedAt:   time.Now(),
+	}
+	attachment.StorageKey = path.Join(strings.ToLower(string(ownerType)), ownerID, attachment.ID)
+
+	hash := sha256.New()
+	var size byteCounter
+	err = s.storage.Save(ctx, attachment.StorageKey, io.TeeReader(io.LimitReader(content, s.maxSize+1), io.MultiWriter(hash, &size)))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error saving attachment file", slog.Any("error", err))
+		return nil, fmt.Errorf("Error saving attachment file: %w", err)
+	}
+
+	if int64(size) > s.maxSize {
+		s.logger.ErrorContext(ctx, "Attachment is too large", slog.Int64("size", int64(size)))
+		s.deleteFile(ctx, attachment.StorageKey)
+		return nil, model.ErrAttachmentTooLarge
+	}
+
+	attachment.Size = int64(size)
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	created, err := s.attachmentRepository.CreateAttachment(ctx, attachment)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error creating attachment", slog.Any("error", err))
+		s.deleteFile(ctx, attachment.StorageKey)
+		return nil, fmt.Errorf("Error creating attachment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *attachmentService) GetAttachments(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, username string) ([]model.Attachment, error) {
+	if err := s.checkAccess(ctx, ownerType, ownerID, username, false); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepository.GetAttachments(ctx, ownerType, ownerID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting attachments", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (s *attachmentService) DownloadAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, attachmentID string, username string) (*model.Attachment, io.ReadCloser, error) {
+	if err := s.checkAccess(ctx, ownerType, ownerID, username, false); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.getOwnedAttachment(ctx, ownerType, ownerID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error opening attachment file", slog.Any("error", err))
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return nil, nil, model.ErrAttachmentNotFound
+		}
+		return nil, nil, fmt.Errorf("Error opening attachment file: %w", err)
+	}
+
+	return attachment, file, nil
+}
+
+func (s *attachmentService) DeleteAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, attachmentID string, username string) error {
+	if err := s.checkAccess(ctx, ownerType, ownerID, username, true); err != nil {
+		return err
+	}
+
+	attachment, err := s.getOwnedAttachment(ctx, ownerType, ownerID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepository.DeleteAttachment(ctx, attachment.ID); err != nil {
+		s.logger.ErrorContext(ctx, "Error deleting attachment", slog.Any("error", err))
+		if errors.Is(err, model.ErrAttachmentNotFound) {
+			return model.ErrAttachmentNotFound
+		}
+		return fmt.Errorf("Error deleting attachment: %w", err)
+	}
+
+	// Запись уже удалена, оставшийся файл не виден пользователям
+	s.deleteFile(ctx, attachment.StorageKey)
+	return nil
+}
+
+func (s *attachmentService) getOwnedAttachment(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, attachmentID string) (*model.Attachment, error) {
+	attachment, err := s.attachmentRepository.GetAttachmentById(ctx, attachmentID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting attachment", slog.Any("error", err))
+		if errors.Is(err, model.ErrAttachmentNotFound) {
+			return nil, model.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("Error getting attachment: %w", err)
+	}
+
+	if attachment.OwnerType != ownerType || attachment.OwnerID != ownerID {
+		return nil, model.ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) deleteFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.ErrorContext(ctx, "Error deleting attachment file", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// Менять вложения тендера может ответственный, предложения - автор.
+// Читать их могут те же, кто видит сам тендер или предложение
+func (s *attachmentService) checkAccess(ctx context.Context, ownerType model.AttachmentOwnerType, ownerID string, username string, write bool) error {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ErrUserNotFound
+		}
+		return fmt.Errorf("Error getting user: %w", err)
+	}
+
+	var tenderID string
+	switch ownerType {
+	case model.AttachmentOwnerTender:
+		tender, err := s.tenderRepository.GetTenderById(ctx, ownerID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+			if errors.Is(err, model.ErrTenderNotFound) {
+				return model.ErrTenderNotFound
+			}
+			return fmt.Errorf("Error getting tender, %w", err)
+		}
+		if !write && tender.Status == model.TenderStatusPublished {
+			return nil
+		}
+		tenderID = tender.ID
+	case model.AttachmentOwnerBid:
+		bid, err := s.bidRepository.GetBidById(ctx, ownerID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+			if errors.Is(err, model.ErrBidNotFound) {
+				return model.ErrBidNotFound
+			}
+			return fmt.Errorf("Error getting bid, %w", err)
+		}
+		if bid.CreatorUsername == username {
+			return nil
+		}
+		if write || (bid.Status != model.BidStatusPublished && bid.Status != model.BidStatusApproved && bid.Status != model.BidStatusRejected) {
+			s.logger.ErrorContext(ctx, "User cannot access bid attachments", slog.String("username", username), slog.String("bidID", bid.ID))
+			return model.ErrForbidden
+		}
+		tenderID = bid.TenderID
+	default:
+		return fmt.Errorf("unknown attachment owner type: %s", ownerType)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, tenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for tender", slog.String("username", username), slog.String("tenderID", tenderID))
+		return model.ErrForbidden
+	}
+
+	return nil
+}
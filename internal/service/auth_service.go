@@ -0,0 +1,58 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type AuthService interface {
+	CreateToken(ctx context.Context, username string, password string) (*model.TokenResponse, error)
+}
+
+type authService struct {
+	userRepository repository.UserRepository
+	secret         []byte
+	ttl            time.Duration
+	logger         *slog.Logger
+}
+
+func NewAuthService(userRepository repository.UserRepository, secret []byte, ttl time.Duration, logger *slog.Logger) AuthService {
+	return &authService{userRepository, secret, ttl, logger}
+}
+
+// CreateToken выдаёт токен по паролю сотрудника. Неизвестный сотрудник, сотрудник без пароля
+// и неверный пароль неразличимы для клиента
+func (s *authService) CreateToken(ctx context.Context, username string, password string) (*model.TokenResponse, error) {
+	passwordHash, err := s.userRepository.GetUserPasswordHash(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user password", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("Error getting user password: %w", err)
+	}
+
+	if passwordHash == "" {
+		s.logger.ErrorContext(ctx, "User has no password", slog.String("username", username))
+		return nil, model.ErrInvalidCredentials
+	}
+
+	if err := utils.CheckPassword(passwordHash, password); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid password", slog.String("username", username))
+		return nil, model.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := utils.GenerateToken(username, s.secret, s.ttl)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error generating token", slog.Any("error", err))
+		return nil, fmt.Errorf("Error generating token: %w", err)
+	}
+
+	return &model.TokenResponse{Token: token, ExpiresAt: expiresAt}, nil
+}
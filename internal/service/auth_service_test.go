@@ -0,0 +1,60 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePasswordRepository хранит хеши паролей по имени сотрудника
+type fakePasswordRepository struct {
+	repository.UserRepository
+	hashes map[string]string
+}
+
+func (r *fakePasswordRepository) GetUserPasswordHash(ctx context.Context, username string) (string, error) {
+	hash, ok := r.hashes[username]
+	if !ok {
+		return "", model.ErrUserNotFound
+	}
+	return hash, nil
+}
+
+func TestCreateToken(t *testing.T) {
+	secret := []byte("secret")
+	hash, err := utils.HashPassword("password")
+	require.NoError(t, err)
+
+	s := NewAuthService(&fakePasswordRepository{hashes: map[string]string{"ivanov": hash, "petrov": ""}}, secret, time.Hour, slog.Default())
+
+	t.Run("valid password", func(t *testing.T) {
+		token, err := s.CreateToken(context.Background(), "ivanov", "password")
+		require.NoError(t, err)
+
+		claims, err := utils.ParseToken(token.Token, secret)
+		require.NoError(t, err)
+		assert.Equal(t, "ivanov", claims.Username)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := s.CreateToken(context.Background(), "ivanov", "wrong")
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+
+	t.Run("no password set", func(t *testing.T) {
+		_, err := s.CreateToken(context.Background(), "petrov", "")
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := s.CreateToken(context.Background(), "sidorov", "password")
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+}
@@ -14,14 +14,23 @@ import (
 
 type BidService interface {
 	CreateBid(ctx context.Context, bid *model.CreateBidRequest) (*model.Bid, error)
-	GetCurrentUserBids(ctx context.Context, limit int, offset int, username string) ([]model.Bid, error)
-	GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string) ([]model.Bid, error)
+	GetCurrentUserBids(ctx context.Context, limit int, offset int, cursor *model.Cursor, username string) ([]model.Bid, error)
+	GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, cursor *model.Cursor, sort model.BidSort, username string) ([]model.Bid, error)
+	SearchBids(ctx context.Context, query string, tenderID string, limit int, offset int, username string) ([]model.BidSearchResult, error)
 	GetBidStatus(ctx context.Context, bidID string, username string) (model.BidStatus, error)
-	UpdateBidStatus(ctx context.Context, bidID string, username string, status string) (model.BidStatus, error)
-	EditBid(ctx context.Context, bidID string, username string, updateData model.UpdateData) (*model.Bid, error)
+	UpdateBidStatus(ctx context.Context, bidID string, username string, status string, expectedVersion int) (*model.Bid, error)
+	EditBid(ctx context.Context, bidID string, username string, updateData model.UpdateData, expectedVersion int) (*model.Bid, error)
 	SubmitBidDecision(ctx context.Context, bidID string, username string, decision string) (*model.Bid, error)
-	AddBidFeedback(ctx context.Context, bidID string, username string, review string) (*model.Bid, error)
+	AddBidFeedback(ctx context.Context, bidID string, username string, review string) (*model.BidReview, error)
 	RollbackBidVersion(ctx context.Context, bidID string, username string, version int) (*model.Bid, error)
+	GetBidReviews(ctx context.Context, tenderID string, authorUsername string, requesterUsername string, limit int, offset int) ([]model.BidReview, error)
+	GetBidDecisions(ctx context.Context, bidID string, username string) ([]model.BidDecisionRecord, error)
+	GetBidVersions(ctx context.Context, bidID string, username string, limit int, offset int) ([]model.Bid, error)
+	GetBidVersion(ctx context.Context, bidID string, username string, version int) (*model.Bid, error)
+	GetBidDiff(ctx context.Context, bidID string, username string, from int, to int) (*model.VersionDiff, error)
+	SubmitBidScores(ctx context.Context, bidID string, username string, scores []model.ScoreInput) ([]model.BidScore, error)
+	GetBidScores(ctx context.Context, bidID string, username string) ([]model.BidScore, error)
+	PlaceAuctionPrice(ctx context.Context, bidID string, username string, price model.Decimal) (*model.Bid, error)
 }
 
 type bidService struct {
@@ -29,16 +38,20 @@ type bidService struct {
 	tenderRepository       repository.TenderRepository
 	organizationRepository repository.OrganizationRepository
 	userRepository         repository.UserRepository
-	logger                 *slog.Logger
+	tenderTransitions      model.TenderStatusTransitions
+	// На сколько продлевается редукцион при ставке перед самым окончанием
+	auctionExtension    time.Duration
+	notificationService NotificationService
+	logger              *slog.Logger
 }
 
-func NewBidService(bidRepository repository.BidRepository, tenderRepository repository.TenderRepository, organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository, logger *slog.Logger) BidService {
-	return &bidService{bidRepository, tenderRepository, organizationRepository, userRepository, logger}
+func NewBidService(bidRepository repository.BidRepository, tenderRepository repository.TenderRepository, organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository, tenderTransitions model.TenderStatusTransitions, auctionExtension time.Duration, notificationService NotificationService, logger *slog.Logger) BidService {
+	return &bidService{bidRepository, tenderRepository, organizationRepository, userRepository, tenderTransitions, auctionExtension, notificationService, logger}
 }
 
 func (s *bidService) CreateBid(ctx context.Context, bidRequest *model.CreateBidRequest) (*model.Bid, error) {
 
-	_, err := s.tenderRepository.GetTenderById(ctx, bidRequest.TenderID)
+	tender, err := s.tenderRepository.GetTenderById(ctx, bidRequest.TenderID)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
 		if errors.Is(err, model.ErrTenderNotFound) {
@@ -47,6 +60,26 @@ func (s *bidService) CreateBid(ctx context.Context, bidRequest *model.CreateBidR
 		return nil, fmt.Errorf("Error getting tender, %w", err)
 	}
 
+	if tender.Status != model.TenderStatusPublished {
+		s.logger.ErrorContext(ctx, "Cannot create bid for tender with status", slog.String("status", string(tender.Status)))
+		return nil, model.ErrTenderNotPublished
+	}
+
+	if tender.IsExpired(time.Now()) {
+		s.logger.ErrorContext(ctx, "Tender submission deadline has passed", slog.String("tenderID", tender.ID))
+		return nil, model.ErrDeadlinePassed
+	}
+
+	if tender.Auction && !tender.IsAuctionOpen(time.Now()) {
+		s.logger.ErrorContext(ctx, "Tender auction is closed", slog.String("tenderID", tender.ID))
+		return nil, model.ErrAuctionClosed
+	}
+
+	if err := tender.CheckBidPrice(bidRequest.Price, bidRequest.Currency); err != nil {
+		s.logger.ErrorContext(ctx, "Bid price does not fit tender budget", slog.Any("error", err))
+		return nil, err
+	}
+
 	var authorID string
 	authorType := model.BidAuthorTypeUser
 	if bidRequest.OrganizationID != "" {
@@ -79,6 +112,8 @@ func (s *bidService) CreateBid(ctx context.Context, bidRequest *model.CreateBidR
 	bid.AuthorType = authorType
 	bid.AuthorID = authorID
 	bid.CreatorUsername = bidRequest.CreatorUsername
+	bid.Price = bidRequest.Price
+	bid.Currency = bidRequest.Currency
 	bid.Version = 1
 	bid.CreatedAt = time.Now()
 	bid.UpdatedAt = time.Now()
@@ -93,7 +128,7 @@ func (s *bidService) CreateBid(ctx context.Context, bidRequest *model.CreateBidR
 
 }
 
-func (s *bidService) GetCurrentUserBids(ctx context.Context, limit int, offset int, username string) ([]model.Bid, error) {
+func (s *bidService) GetCurrentUserBids(ctx context.Context, limit int, offset int, cursor *model.Cursor, username string) ([]model.Bid, error) {
 	_, err := s.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
@@ -103,7 +138,7 @@ func (s *bidService) GetCurrentUserBids(ctx context.Context, limit int, offset i
 		return nil, fmt.Errorf("Error getting user: %w", err)
 	}
 
-	bid, err := s.BidRepository.GetBidByUsername(ctx, limit, offset, username)
+	bid, err := s.BidRepository.GetBidByUsername(ctx, limit, offset, cursor, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
 		return nil, fmt.Errorf("Error getting bids, %w", err)
@@ -111,7 +146,25 @@ func (s *bidService) GetCurrentUserBids(ctx context.Context, limit int, offset i
 	return bid, nil
 }
 
-func (s *bidService) GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, username string) ([]model.Bid, error) {
+func (s *bidService) SearchBids(ctx context.Context, query string, tenderID string, limit int, offset int, username string) ([]model.BidSearchResult, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	results, err := s.BidRepository.SearchBids(ctx, query, tenderID, limit, offset, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error searching bids", slog.Any("error", err))
+		return nil, fmt.Errorf("Error searching bids, %w", err)
+	}
+	return results, nil
+}
+
+func (s *bidService) GetTenderBids(ctx context.Context, tenderID string, limit int, offset int, cursor *model.Cursor, sort model.BidSort, username string) ([]model.Bid, error) {
 
 	_, err := s.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
@@ -131,7 +184,7 @@ func (s *bidService) GetTenderBids(ctx context.Context, tenderID string, limit i
 		return nil, fmt.Errorf("Error getting tender, %w", err)
 	}
 
-	bids, err := s.BidRepository.GetTenderBids(ctx, tenderID, limit, offset, username)
+	bids, err := s.BidRepository.GetTenderBids(ctx, tenderID, limit, offset, cursor, sort, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting bids", slog.Any("error", err))
 		if errors.Is(err, model.ErrBidNotFound) {
@@ -144,53 +197,55 @@ func (s *bidService) GetTenderBids(ctx context.Context, tenderID string, limit i
 
 func (s *bidService) GetBidStatus(ctx context.Context, bidID string, username string) (model.BidStatus, error) {
 
-	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	bid, err := s.getVisibleBid(ctx, bidID, username)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
-		if errors.Is(err, model.ErrUserNotFound) {
-			return "", model.ErrUserNotFound
-		}
-		return "", fmt.Errorf("Error getting user: %w", err)
+		return "", err
 	}
 
-	status, err := s.BidRepository.GetBidStatus(ctx, bidID)
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Error getting bid status", slog.Any("error", err))
-		if errors.Is(err, model.ErrBidNotFound) {
-			return "", model.ErrBidNotFound
-		}
-		return "", fmt.Errorf("Error getting bid status, %w", err)
-	}
-	return status, nil
+	return bid.Status, nil
 }
 
-func (s *bidService) UpdateBidStatus(ctx context.Context, bidID string, username string, status string) (model.BidStatus, error) {
+func (s *bidService) UpdateBidStatus(ctx context.Context, bidID string, username string, status string, expectedVersion int) (*model.Bid, error) {
 	_, err := s.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
 		if errors.Is(err, model.ErrUserNotFound) {
-			return "", model.ErrUserNotFound
+			return nil, model.ErrUserNotFound
 		}
-		return "", fmt.Errorf("Error getting user: %w", err)
+		return nil, fmt.Errorf("Error getting user: %w", err)
 	}
 
 	bid, err := s.BidRepository.GetBidById(ctx, bidID)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
 		if errors.Is(err, model.ErrBidNotFound) {
-			return "", model.ErrBidNotFound
+			return nil, model.ErrBidNotFound
 		}
-		return "", fmt.Errorf("Error getting bid, %w", err)
+		return nil, fmt.Errorf("Error getting bid, %w", err)
 	}
 
-	isResponsible, err := s.organizationRepository.IsUserResponsibleForOrganization(ctx, bid.AuthorID, username)
+	isAuthor, err := s.isBidAuthor(ctx, bid, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting responsible for organization", slog.Any("error", err))
-		return "", fmt.Errorf("Error getting responsible for organization, %w", err)
+		return nil, fmt.Errorf("Error getting responsible for organization, %w", err)
 	}
-	if !isResponsible {
-		s.logger.ErrorContext(ctx, "User is not responsible for organization", slog.Any("error", err))
-		return "", model.ErrForbidden
+	if !isAuthor {
+		s.logger.ErrorContext(ctx, "User is not the author of the bid", slog.String("username", username), slog.String("bidID", bidID))
+		return nil, model.ErrForbidden
+	}
+
+	if expectedVersion != 0 && bid.Version != expectedVersion {
+		s.logger.ErrorContext(ctx, "Bid version mismatch", slog.Int("expected", expectedVersion), slog.Int("actual", bid.Version))
+		return nil, model.ErrVersionConflict
+	}
+
+	if bid.Status == model.BidStatus(status) {
+		return bid, nil
+	}
+
+	if err := model.CheckBidTransition(model.BidActorAuthor, bid.Status, model.BidStatus(status)); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid bid status transition", slog.Any("error", err))
+		return nil, err
 	}
 
 	bid.Status = model.BidStatus(status)
@@ -198,13 +253,13 @@ func (s *bidService) UpdateBidStatus(ctx context.Context, bidID string, username
 	bid, err = s.BidRepository.UpdateBid(ctx, bid)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error updating bid", slog.Any("error", err))
-		return "", fmt.Errorf("Error updating bid, %w", err)
+		return nil, fmt.Errorf("Error updating bid, %w", err)
 	}
-	return bid.Status, nil
+	return bid, nil
 
 }
 
-func (s *bidService) EditBid(ctx context.Context, bidID string, username string, updateData model.UpdateData) (*model.Bid, error) {
+func (s *bidService) EditBid(ctx context.Context, bidID string, username string, updateData model.UpdateData, expectedVersion int) (*model.Bid, error) {
 	_, err := s.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
@@ -228,6 +283,16 @@ func (s *bidService) EditBid(ctx context.Context, bidID string, username string,
 		return nil, model.ErrForbidden
 	}
 
+	if bid.Status.IsTerminal() {
+		s.logger.ErrorContext(ctx, "Cannot edit bid with terminal status", slog.String("status", string(bid.Status)))
+		return nil, model.ErrBidNotEditable
+	}
+
+	if expectedVersion != 0 && bid.Version != expectedVersion {
+		s.logger.ErrorContext(ctx, "Bid version mismatch", slog.Int("expected", expectedVersion), slog.Int("actual", bid.Version))
+		return nil, model.ErrVersionConflict
+	}
+
 	if updateData.Name != nil {
 		if *updateData.Name != "" {
 			bid.Name = *updateData.Name
@@ -240,6 +305,39 @@ func (s *bidService) EditBid(ctx context.Context, bidID string, username string,
 		}
 	}
 
+	tender, err := s.tenderRepository.GetTenderById(ctx, bid.TenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("Error getting tender, %w", err)
+	}
+
+	if tender.IsExpired(time.Now()) {
+		s.logger.ErrorContext(ctx, "Tender submission deadline has passed", slog.String("tenderID", tender.ID))
+		return nil, model.ErrDeadlinePassed
+	}
+
+	// Цена опубликованного предложения в редукционе меняется только через ставку
+	if tender.Auction && bid.Status == model.BidStatusPublished && (updateData.Price != nil || updateData.Currency != nil) {
+		s.logger.ErrorContext(ctx, "Cannot edit auction bid price", slog.String("bidID", bid.ID))
+		return nil, model.ErrAuctionPriceLocked
+	}
+
+	if updateData.Price != nil {
+		bid.Price = updateData.Price
+	}
+
+	if updateData.Currency != nil {
+		bid.Currency = updateData.Currency
+	}
+
+	if err := tender.CheckBidPrice(bid.Price, bid.Currency); err != nil {
+		s.logger.ErrorContext(ctx, "Bid price does not fit tender budget", slog.Any("error", err))
+		return nil, err
+	}
+
 	bid, err = s.BidRepository.UpdateBid(ctx, bid)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error updating bid", slog.Any("error", err))
@@ -272,9 +370,39 @@ func (s *bidService) RollbackBidVersion(ctx context.Context, bidID string, usern
 		return nil, model.ErrForbidden
 	}
 
-	bid, err = s.BidRepository.RollbackBidVersion(ctx, bidID, version)
+	if bid.Status.IsTerminal() {
+		s.logger.ErrorContext(ctx, "Cannot edit bid with terminal status", slog.String("status", string(bid.Status)))
+		return nil, model.ErrBidNotEditable
+	}
+
+	tender, err := s.tenderRepository.GetTenderById(ctx, bid.TenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("Error getting tender, %w", err)
+	}
+
+	// Откат вернул бы прежнюю, более высокую цену в обход правил редукциона
+	if tender.Auction {
+		s.logger.ErrorContext(ctx, "Cannot roll back auction bid", slog.String("bidID", bid.ID))
+		return nil, model.ErrAuctionPriceLocked
+	}
+
+	// Восстановленная версия проходит те же проверки, что и правка
+	bid, err = s.BidRepository.RollbackBidVersion(ctx, bidID, version, func(restored *model.Bid) error {
+		if tender.IsExpired(time.Now()) {
+			return model.ErrDeadlinePassed
+		}
+		return tender.CheckBidPrice(restored.Price, restored.Currency)
+	})
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error rolling back bid version", slog.Any("error", err))
+		if errors.Is(err, model.ErrVersionNotFound) || errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrVersionConflict) ||
+			errors.Is(err, model.ErrDeadlinePassed) || errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrBidOutOfBudget) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("Error rolling back bid version, %w", err)
 	}
 	return bid, nil
@@ -318,37 +446,53 @@ func (s *bidService) SubmitBidDecision(ctx context.Context, bidID string, userna
 		return nil, model.ErrForbidden
 	}
 
-	if bid.Status != model.BidStatusPublished && bid.Status != model.BidStatusCreated {
-		s.logger.ErrorContext(ctx, "Cannot submit decision for bid with status", slog.String("status", string(bid.Status)))
+	if decision != string(model.BidDecisionApproved) && decision != string(model.BidDecisionRejected) {
+		s.logger.ErrorContext(ctx, "Invalid decision parameter", slog.String("decision", decision))
 		return nil, model.ErrDecisionSubmit
 	}
 
-	if decision == "Approved" {
-		bid.Status = model.BidStatusApproved
-		tender.Status = model.TenderStatusClosed
-		_, err = s.tenderRepository.UpdateTender(ctx, tender)
-		if err != nil {
-			s.logger.ErrorContext(ctx, "Error updating tender status to closed", slog.Any("error", err))
-			return nil, fmt.Errorf("Error updating tender status to closed: %w", err)
-		}
+	bidDecision := &model.BidDecisionRecord{}
 
-	} else if decision == "Rejected" {
-		bid.Status = model.BidStatusRejected
-	} else {
-		s.logger.ErrorContext(ctx, "Invalid decision parameter", slog.String("decision", decision))
-		return nil, model.ErrDecisionSubmit
+	bidDecision.ID = uuid.NewString()
+	bidDecision.BidID = bid.ID
+	bidDecision.Username = username
+	bidDecision.Decision = model.BidDecision(decision)
+	bidDecision.CreatedAt = time.Now()
+
+	// Статусы проверяются под блокировкой, чтобы одновременные решения не закрыли тендер дважды
+	check := func(tender *model.Tender, bid *model.Bid) error {
+		if err := model.CheckBidTransition(model.BidActorResponsible, bid.Status, model.BidStatus(decision)); err != nil {
+			return err
+		}
+		// Одобрение может закрыть тендер, поэтому переход проверяется до записи решения
+		if bidDecision.Decision == model.BidDecisionApproved {
+			return s.tenderTransitions.Check(tender.Status, model.TenderStatusClosed)
+		}
+		return nil
 	}
 
-	updatedBid, err := s.BidRepository.UpdateBid(ctx, bid)
+	updatedBid, closedTender, err := s.BidRepository.SubmitBidDecision(ctx, bidDecision, check)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Error updating bid status on decision", slog.Any("error", err))
-		return nil, fmt.Errorf("Error updating bid status on decision: %w", err)
+		s.logger.ErrorContext(ctx, "Error submitting bid decision", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) || errors.Is(err, model.ErrDecisionExists) || errors.Is(err, model.ErrInvalidTransition) || errors.Is(err, model.ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Error submitting bid decision: %w", err)
+	}
+
+	if err := s.notificationService.NotifyBidDecision(ctx, updatedBid); err != nil {
+		s.logger.ErrorContext(ctx, "Error notifying about bid decision", slog.Any("error", err))
+	}
+	if closedTender != nil {
+		if err := s.notificationService.NotifyTenderClosed(ctx, closedTender); err != nil {
+			s.logger.ErrorContext(ctx, "Error notifying about closed tender", slog.Any("error", err))
+		}
 	}
 
 	return updatedBid, nil
 }
 
-func (s *bidService) AddBidFeedback(ctx context.Context, bidID string, username string, review string) (*model.Bid, error) {
+func (s *bidService) AddBidFeedback(ctx context.Context, bidID string, username string, review string) (*model.BidReview, error) {
 	_, err := s.userRepository.GetUserByUsername(ctx, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
@@ -358,7 +502,7 @@ func (s *bidService) AddBidFeedback(ctx context.Context, bidID string, username
 		return nil, fmt.Errorf("Error getting user: %w", err)
 	}
 
-	_, err = s.BidRepository.GetBidById(ctx, bidID)
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
 		if errors.Is(err, model.ErrBidNotFound) {
@@ -367,11 +511,412 @@ func (s *bidService) AddBidFeedback(ctx context.Context, bidID string, username
 		return nil, fmt.Errorf("Error getting bid, %w", err)
 	}
 
-	updatedBid, err := s.BidRepository.AddBidFeedback(ctx, bidID, username, review)
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, bid.TenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for this tender", slog.String("username", username), slog.String("tenderID", bid.TenderID))
+		return nil, model.ErrForbidden
+	}
+
+	bidReview := &model.BidReview{}
+
+	bidReview.ID = uuid.NewString()
+	bidReview.BidID = bid.ID
+	bidReview.AuthorUsername = username
+	bidReview.Description = review
+	bidReview.CreatedAt = time.Now()
+	bidReview.UpdatedAt = time.Now()
+
+	createdReview, err := s.BidRepository.CreateBidReview(ctx, bidReview)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error adding bid feedback", slog.Any("error", err))
 		return nil, fmt.Errorf("Error adding bid feedback: %w", err)
 	}
 
-	return updatedBid, nil
+	if err := s.notificationService.NotifyBidFeedback(ctx, bid, review); err != nil {
+		s.logger.ErrorContext(ctx, "Error notifying about bid feedback", slog.Any("error", err))
+	}
+
+	return createdReview, nil
+}
+
+func (s *bidService) GetBidReviews(ctx context.Context, tenderID string, authorUsername string, requesterUsername string, limit int, offset int) ([]model.BidReview, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, requesterUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting requester", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting requester: %w", err)
+	}
+
+	_, err = s.userRepository.GetUserByUsername(ctx, authorUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting author", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting author: %w", err)
+	}
+
+	tender, err := s.tenderRepository.GetTenderById(ctx, tenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("Error getting tender, %w", err)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, tender.ID, requesterUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for this tender", slog.String("username", requesterUsername), slog.String("tenderID", tender.ID))
+		return nil, model.ErrForbidden
+	}
+
+	// Отзывы раскрываются только об авторах, чьи предложения организация тендера видит
+	hasBid, err := s.BidRepository.HasTenderBidByAuthor(ctx, tender.ID, authorUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking tender bid author", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking tender bid author: %w", err)
+	}
+	if !hasBid {
+		s.logger.ErrorContext(ctx, "Author has no bids on this tender", slog.String("author", authorUsername), slog.String("tenderID", tender.ID))
+		return nil, model.ErrBidNotFound
+	}
+
+	reviews, err := s.BidRepository.GetBidReviews(ctx, authorUsername, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid reviews", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting bid reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (s *bidService) GetBidDecisions(ctx context.Context, bidID string, username string) ([]model.BidDecisionRecord, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid, %w", err)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, bid.TenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for this tender", slog.String("username", username), slog.String("tenderID", bid.TenderID))
+		return nil, model.ErrForbidden
+	}
+
+	decisions, err := s.BidRepository.GetBidDecisions(ctx, bid.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid decisions", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting bid decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
+func (s *bidService) SubmitBidScores(ctx context.Context, bidID string, username string, input []model.ScoreInput) ([]model.BidScore, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid, %w", err)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, bid.TenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for this tender", slog.String("username", username), slog.String("tenderID", bid.TenderID))
+		return nil, model.ErrForbidden
+	}
+
+	// Оцениваются только предложения на рассмотрении, решённые уже не переранжируются
+	if bid.Status != model.BidStatusPublished {
+		s.logger.ErrorContext(ctx, "Cannot score bid with status", slog.String("status", string(bid.Status)))
+		return nil, model.ErrBidNotScorable
+	}
+
+	criteria, err := s.tenderRepository.GetTenderCriteria(ctx, bid.TenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender criteria", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting tender criteria: %w", err)
+	}
+
+	tenderCriteria := make(map[string]bool, len(criteria))
+	for _, criterion := range criteria {
+		tenderCriteria[criterion.ID] = true
+	}
+
+	scores := make([]model.BidScore, len(input))
+	for i, score := range input {
+		if !tenderCriteria[score.CriterionID] {
+			s.logger.ErrorContext(ctx, "Criterion does not belong to the tender", slog.String("criterionID", score.CriterionID), slog.String("tenderID", bid.TenderID))
+			return nil, model.ErrCriterionNotFound
+		}
+		scores[i] = model.BidScore{
+			ID:          uuid.NewString(),
+			BidID:       bid.ID,
+			CriterionID: score.CriterionID,
+			Username:    username,
+			Score:       score.Score,
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	saved, err := s.BidRepository.SaveBidScores(ctx, scores)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error saving bid scores", slog.Any("error", err))
+		return nil, fmt.Errorf("Error saving bid scores: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *bidService) GetBidScores(ctx context.Context, bidID string, username string) ([]model.BidScore, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid, %w", err)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, bid.TenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for this tender", slog.String("username", username), slog.String("tenderID", bid.TenderID))
+		return nil, model.ErrForbidden
+	}
+
+	scores, err := s.BidRepository.GetBidScores(ctx, bid.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid scores", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting bid scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+func (s *bidService) PlaceAuctionPrice(ctx context.Context, bidID string, username string, price model.Decimal) (*model.Bid, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid, %w", err)
+	}
+
+	if bid.CreatorUsername != username {
+		s.logger.ErrorContext(ctx, "User is not responsible for bid", slog.String("username", username), slog.String("bidID", bid.ID))
+		return nil, model.ErrForbidden
+	}
+
+	if bid.Status != model.BidStatusPublished {
+		s.logger.ErrorContext(ctx, "Cannot place auction price for bid with status", slog.String("status", string(bid.Status)))
+		return nil, model.ErrBidNotInAuction
+	}
+
+	tender, err := s.tenderRepository.GetTenderById(ctx, bid.TenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, fmt.Errorf("Error getting tender, %w", err)
+	}
+
+	if !tender.Auction {
+		s.logger.ErrorContext(ctx, "Tender is not an auction", slog.String("tenderID", tender.ID))
+		return nil, model.ErrNotAuction
+	}
+
+	now := time.Now()
+	if tender.Status != model.TenderStatusPublished || !tender.IsAuctionOpen(now) || tender.IsExpired(now) {
+		s.logger.ErrorContext(ctx, "Tender auction is closed", slog.String("tenderID", tender.ID))
+		return nil, model.ErrAuctionClosed
+	}
+
+	currency := bid.Currency
+	if currency == nil {
+		currency = tender.BudgetCurrency
+	}
+	if err := tender.CheckBidPrice(&price, currency); err != nil {
+		s.logger.ErrorContext(ctx, "Bid price does not fit tender budget", slog.Any("error", err))
+		return nil, err
+	}
+
+	bid.Price = &price
+	bid.Currency = currency
+
+	// Окончание торгов и текущий минимум перепроверяются под блокировкой тендера
+	bid, err = s.BidRepository.PlaceAuctionPrice(ctx, bid, now, s.auctionExtension)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error placing auction price", slog.Any("error", err))
+		if errors.Is(err, model.ErrAuctionPriceTooHigh) || errors.Is(err, model.ErrAuctionClosed) || errors.Is(err, model.ErrNotAuction) || errors.Is(err, model.ErrCurrencyMismatch) || errors.Is(err, model.ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Error placing auction price, %w", err)
+	}
+	return bid, nil
+}
+
+func (s *bidService) isBidAuthor(ctx context.Context, bid *model.Bid, username string) (bool, error) {
+	if bid.AuthorType == model.BidAuthorTypeUser {
+		return bid.CreatorUsername == username, nil
+	}
+	return s.organizationRepository.IsUserResponsibleForOrganization(ctx, bid.AuthorID, username)
+}
+
+func (s *bidService) canViewBid(ctx context.Context, bid *model.Bid, username string) (bool, error) {
+	isAuthor, err := s.isBidAuthor(ctx, bid, username)
+	if err != nil || isAuthor {
+		return isAuthor, err
+	}
+
+	if !bid.Status.IsVisibleToTenderResponsibles() {
+		return false, nil
+	}
+	return s.tenderRepository.IsUserResponsibleForTender(ctx, bid.TenderID, username)
+}
+
+func (s *bidService) GetBidVersions(ctx context.Context, bidID string, username string, limit int, offset int) ([]model.Bid, error) {
+	_, err := s.getVisibleBid(ctx, bidID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.BidRepository.GetBidVersions(ctx, bidID, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid versions", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting bid versions, %w", err)
+	}
+	return versions, nil
+}
+
+func (s *bidService) GetBidVersion(ctx context.Context, bidID string, username string, version int) (*model.Bid, error) {
+	_, err := s.getVisibleBid(ctx, bidID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	bid, err := s.BidRepository.GetBidVersion(ctx, bidID, version)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid version", slog.Any("error", err))
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return nil, model.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid version, %w", err)
+	}
+	return bid, nil
+}
+
+func (s *bidService) GetBidDiff(ctx context.Context, bidID string, username string, from int, to int) (*model.VersionDiff, error) {
+	fromBid, err := s.GetBidVersion(ctx, bidID, username, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toBid, err := s.BidRepository.GetBidVersion(ctx, bidID, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid version", slog.Any("error", err))
+		if errors.Is(err, model.ErrVersionNotFound) {
+			return nil, model.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid version, %w", err)
+	}
+
+	return model.DiffBids(fromBid, toBid), nil
+}
+
+func (s *bidService) getVisibleBid(ctx context.Context, bidID string, username string) (*model.Bid, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	bid, err := s.BidRepository.GetBidById(ctx, bidID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting bid", slog.Any("error", err))
+		if errors.Is(err, model.ErrBidNotFound) {
+			return nil, model.ErrBidNotFound
+		}
+		return nil, fmt.Errorf("Error getting bid, %w", err)
+	}
+
+	canView, err := s.canViewBid(ctx, bid, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking bid visibility", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking bid visibility, %w", err)
+	}
+	if !canView {
+		s.logger.ErrorContext(ctx, "User cannot see the bid", slog.String("username", username), slog.String("bidID", bidID))
+		return nil, model.ErrForbidden
+	}
+
+	return bid, nil
 }
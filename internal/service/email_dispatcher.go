@@ -0,0 +1,164 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/notifier"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// Сколько писем берётся в работу за один запрос к базе
+	emailBatchSize = 20
+	// Потолок паузы между повторами
+	maxEmailBackoff = 6 * time.Hour
+)
+
+// EmailDispatcher отправляет письма из очереди уведомлений с повторами, вне запросов к API
+type EmailDispatcher interface {
+	Run(context.Context)
+	DispatchDueEmails(context.Context) (int, error)
+}
+
+type emailDispatcher struct {
+	NotificationRepository repository.NotificationRepository
+	userRepository         repository.UserRepository
+	notifier               notifier.Notifier
+	interval               time.Duration
+	timeout                time.Duration
+	maxAttempts            int
+	retryBase              time.Duration
+	logger                 *slog.Logger
+}
+
+func NewEmailDispatcher(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository, notifier notifier.Notifier, interval time.Duration, timeout time.Duration, maxAttempts int, retryBase time.Duration, logger *slog.Logger) EmailDispatcher {
+	return &emailDispatcher{notificationRepository, userRepository, notifier, interval, timeout, maxAttempts, retryBase, logger}
+}
+
+// Run отправляет письма, время которых пришло, раз в interval, пока не отменён контекст
+func (d *emailDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		dispatched, err := d.DispatchDueEmails(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.ErrorContext(ctx, "Error dispatching notification emails", slog.Any("error", err))
+		}
+		if dispatched > 0 {
+			d.logger.InfoContext(ctx, "Dispatched notification emails", slog.Int("count", dispatched))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *emailDispatcher) DispatchDueEmails(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		now := time.Now()
+		// Письма откладываются на время всей пачки, чтобы другой экземпляр не взял их повторно
+		leaseUntil := now.Add(d.timeout*emailBatchSize + time.Minute)
+		dispatches, err := d.NotificationRepository.ClaimNotificationEmails(ctx, now, leaseUntil, emailBatchSize)
+		if err != nil {
+			return dispatched, fmt.Errorf("Error claiming notification emails, %w", err)
+		}
+
+		for i := range dispatches {
+			if err := d.deliver(ctx, &dispatches[i]); err != nil {
+				return dispatched, err
+			}
+			dispatched++
+		}
+
+		if len(dispatches) < emailBatchSize {
+			return dispatched, nil
+		}
+	}
+}
+
+func (d *emailDispatcher) deliver(ctx context.Context, dispatch *model.NotificationEmailDispatch) error {
+	sent, sendErr := d.send(ctx, dispatch)
+	if ctx.Err() != nil {
+		// Отправку прервала остановка сервиса - письмо вернётся в очередь после аренды
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	email := &dispatch.NotificationEmail
+	email.Attempts++
+
+	switch {
+	case sendErr == nil && !sent:
+		email.Status = model.NotificationEmailSkipped
+		email.LastError = nil
+	case sendErr == nil:
+		email.Status = model.NotificationEmailSent
+		email.LastError = nil
+		email.SentAt = &now
+	default:
+		lastError := sendErr.Error()
+		email.LastError = &lastError
+		if email.Attempts >= d.maxAttempts {
+			email.Status = model.NotificationEmailFailed
+		} else {
+			email.NextAttemptAt = now.Add(d.backoff(email.Attempts))
+		}
+		d.logger.WarnContext(ctx, "Notification email attempt failed", slog.String("notificationID", email.NotificationID), slog.Int("attempts", email.Attempts), slog.Any("error", sendErr))
+	}
+
+	if err := d.NotificationRepository.UpdateNotificationEmail(ctx, email); err != nil {
+		return fmt.Errorf("Error saving notification email %s, %w", email.NotificationID, err)
+	}
+
+	return nil
+}
+
+// send берёт адрес, язык и согласие на письма у сотрудника в момент отправки.
+// Если писать некому, возвращает false без ошибки
+func (d *emailDispatcher) send(ctx context.Context, dispatch *model.NotificationEmailDispatch) (bool, error) {
+	user, err := d.userRepository.GetUserByUsername(ctx, dispatch.Username)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	if user.Email == nil || !user.EmailNotifications {
+		return false, nil
+	}
+
+	msg, err := notifier.RenderBidEmail(user.Language, dispatch.Type, *user.Email, notifier.BidEmailData{
+		FirstName: user.First_name,
+		BidName:   dispatch.BidName,
+		TenderID:  dispatch.TenderID,
+		Feedback:  dispatch.Feedback,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	if err := d.notifier.Send(sendCtx, msg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// backoff удваивает паузу после каждой неудачной попытки
+func (d *emailDispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryBase
+	for i := 1; i < attempts && delay < maxEmailBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxEmailBackoff)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchDueEmails(t *testing.T) {
+	email := "ivanov@example.com"
+
+	newDispatch := func(attempts int) model.NotificationEmailDispatch {
+		return model.NotificationEmailDispatch{
+			NotificationEmail: model.NotificationEmail{
+				NotificationID: "notification-1",
+				Status:         model.NotificationEmailPending,
+				Attempts:       attempts,
+			},
+			Username: "ivanov",
+			Type:     model.NotificationBidRejected,
+			TenderID: "tender-1",
+			BidName:  "Поставка труб",
+		}
+	}
+	newDispatcher := func(user *model.User, sender *fakeNotifier, dispatch model.NotificationEmailDispatch, maxAttempts int) (*emailDispatcher, *fakeNotificationRepository) {
+		repo := &fakeNotificationRepository{dispatches: []model.NotificationEmailDispatch{dispatch}}
+		return &emailDispatcher{
+			NotificationRepository: repo,
+			userRepository:         &fakeUserRepository{user: user},
+			notifier:               sender,
+			interval:               time.Second,
+			timeout:                time.Second,
+			maxAttempts:            maxAttempts,
+			retryBase:              time.Minute,
+			logger:                 slog.Default(),
+		}, repo
+	}
+
+	t.Run("sent", func(t *testing.T) {
+		sender := &fakeNotifier{}
+		d, repo := newDispatcher(&model.User{Username: "ivanov", Email: &email, Language: model.LanguageEn, EmailNotifications: true}, sender, newDispatch(0), 3)
+
+		dispatched, err := d.DispatchDueEmails(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		require.Len(t, sender.sent, 1)
+		assert.Equal(t, email, sender.sent[0].To)
+		assert.Equal(t, `Bid "Поставка труб" rejected`, sender.sent[0].Subject)
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.NotificationEmailSent, repo.updated[0].Status)
+		assert.Equal(t, 1, repo.updated[0].Attempts)
+		assert.NotNil(t, repo.updated[0].SentAt)
+	})
+
+	t.Run("email opt-out", func(t *testing.T) {
+		sender := &fakeNotifier{}
+		d, repo := newDispatcher(&model.User{Username: "ivanov", Email: &email, Language: model.LanguageRu, EmailNotifications: false}, sender, newDispatch(0), 3)
+
+		_, err := d.DispatchDueEmails(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, sender.sent)
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.NotificationEmailSkipped, repo.updated[0].Status)
+	})
+
+	t.Run("no email address", func(t *testing.T) {
+		sender := &fakeNotifier{}
+		d, repo := newDispatcher(&model.User{Username: "ivanov", Language: model.LanguageRu, EmailNotifications: true}, sender, newDispatch(0), 3)
+
+		_, err := d.DispatchDueEmails(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, sender.sent)
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.NotificationEmailSkipped, repo.updated[0].Status)
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		sender := &fakeNotifier{err: errors.New("connection refused")}
+		d, repo := newDispatcher(&model.User{Username: "ivanov", Email: &email, EmailNotifications: true}, sender, newDispatch(1), 5)
+
+		start := time.Now()
+		_, err := d.DispatchDueEmails(context.Background())
+		assert.NoError(t, err)
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.NotificationEmailPending, repo.updated[0].Status)
+		assert.Equal(t, 2, repo.updated[0].Attempts)
+		assert.Equal(t, "connection refused", *repo.updated[0].LastError)
+		// Вторая неудача - пауза 2 базовых интервала
+		assert.WithinDuration(t, start.Add(2*time.Minute), repo.updated[0].NextAttemptAt, 5*time.Second)
+	})
+
+	t.Run("failed after max attempts", func(t *testing.T) {
+		sender := &fakeNotifier{err: errors.New("connection refused")}
+		d, repo := newDispatcher(&model.User{Username: "ivanov", Email: &email, EmailNotifications: true}, sender, newDispatch(2), 3)
+
+		_, err := d.DispatchDueEmails(context.Background())
+		assert.NoError(t, err)
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.NotificationEmailFailed, repo.updated[0].Status)
+		assert.Equal(t, 3, repo.updated[0].Attempts)
+	})
+}
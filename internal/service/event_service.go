@@ -0,0 +1,94 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/publisher"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Сколько сохранённых событий читается за один запрос к базе
+const tenderEventsBatchSize = 100
+
+type EventService interface {
+	CheckTenderAccess(ctx context.Context, tenderID string, username string) error
+	GetTenderEvents(ctx context.Context, tenderID string, afterSeq int64) ([]model.Event, int64, error)
+	SubscribeTender(tenderID string) (<-chan struct{}, func())
+}
+
+type eventService struct {
+	outboxRepository repository.OutboxRepository
+	tenderRepository repository.TenderRepository
+	userRepository   repository.UserRepository
+	broadcaster      *publisher.Broadcaster
+	logger           *slog.Logger
+}
+
+func NewEventService(outboxRepository repository.OutboxRepository, tenderRepository repository.TenderRepository, userRepository repository.UserRepository, broadcaster *publisher.Broadcaster, logger *slog.Logger) EventService {
+	return &eventService{outboxRepository, tenderRepository, userRepository, broadcaster, logger}
+}
+
+// CheckTenderAccess пускает в поток событий только ответственных за тендер
+func (s *eventService) CheckTenderAccess(ctx context.Context, tenderID string, username string) error {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ErrUserNotFound
+		}
+		return fmt.Errorf("Error getting user, %w", err)
+	}
+
+	_, err = s.tenderRepository.GetTenderById(ctx, tenderID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if errors.Is(err, model.ErrTenderNotFound) {
+			return model.ErrTenderNotFound
+		}
+		return fmt.Errorf("Error getting tender, %w", err)
+	}
+
+	isResponsible, err := s.tenderRepository.IsUserResponsibleForTender(ctx, tenderID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user responsibility for tender", slog.Any("error", err))
+		return fmt.Errorf("Error checking user responsibility for tender: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for tender", slog.String("username", username), slog.String("tenderID", tenderID))
+		return model.ErrForbidden
+	}
+
+	return nil
+}
+
+// GetTenderEvents возвращает видимые ответственным события после номера публикации afterSeq и курсор для следующего чтения.
+// Курсор сдвигается и по скрытым событиям, чтобы неопубликованные предложения не останавливали поток
+func (s *eventService) GetTenderEvents(ctx context.Context, tenderID string, afterSeq int64) ([]model.Event, int64, error) {
+	events, err := s.outboxRepository.GetTenderEvents(ctx, tenderID, afterSeq, tenderEventsBatchSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender events", slog.Any("error", err))
+		return nil, afterSeq, fmt.Errorf("Error getting tender events, %w", err)
+	}
+
+	cursor := afterSeq
+	var visibleEvents []model.Event
+	for _, event := range events {
+		cursor = event.Sequence
+		visible, err := event.VisibleToTender()
+		if err != nil {
+			return nil, afterSeq, fmt.Errorf("Error checking event visibility, %w", err)
+		}
+		if visible {
+			visibleEvents = append(visibleEvents, event)
+		}
+	}
+
+	return visibleEvents, cursor, nil
+}
+
+func (s *eventService) SubscribeTender(tenderID string) (<-chan struct{}, func()) {
+	return s.broadcaster.Subscribe(tenderID)
+}
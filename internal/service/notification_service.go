@@ -0,0 +1,174 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationService ведёт ленту уведомлений сотрудника и ставит в очередь письма о решениях и отзывах.
+// Notify* вызываются сервисами после успешной операции; ошибка уведомления не отменяет саму операцию
+type NotificationService interface {
+	GetNotifications(ctx context.Context, username string, unreadOnly bool, limit int, offset int) ([]model.Notification, error)
+	CountUnreadNotifications(ctx context.Context, username string) (int, error)
+	MarkNotificationRead(ctx context.Context, id string, username string) (*model.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, username string) (int, error)
+	NotifyBidDecision(ctx context.Context, bid *model.Bid) error
+	NotifyBidFeedback(ctx context.Context, bid *model.Bid, feedback string) error
+	NotifyTenderClosed(ctx context.Context, tender *model.Tender) error
+}
+
+type notificationService struct {
+	NotificationRepository repository.NotificationRepository
+	userRepository         repository.UserRepository
+	logger                 *slog.Logger
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository, logger *slog.Logger) NotificationService {
+	return &notificationService{notificationRepository, userRepository, logger}
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, username string, unreadOnly bool, limit int, offset int) ([]model.Notification, error) {
+	if err := s.checkUser(ctx, username); err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.NotificationRepository.GetNotifications(ctx, username, unreadOnly, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting notifications", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting notifications, %w", err)
+	}
+	return notifications, nil
+}
+
+func (s *notificationService) CountUnreadNotifications(ctx context.Context, username string) (int, error) {
+	if err := s.checkUser(ctx, username); err != nil {
+		return 0, err
+	}
+
+	count, err := s.NotificationRepository.CountUnreadNotifications(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error counting unread notifications", slog.Any("error", err))
+		return 0, fmt.Errorf("Error counting unread notifications, %w", err)
+	}
+	return count, nil
+}
+
+func (s *notificationService) MarkNotificationRead(ctx context.Context, id string, username string) (*model.Notification, error) {
+	if err := s.checkUser(ctx, username); err != nil {
+		return nil, err
+	}
+
+	// Чужое уведомление не находится, чтобы не раскрывать его существование
+	notification, err := s.NotificationRepository.MarkNotificationRead(ctx, id, username, time.Now())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error marking notification read", slog.Any("error", err))
+		if errors.Is(err, model.ErrNotificationNotFound) {
+			return nil, model.ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("Error marking notification read, %w", err)
+	}
+	return notification, nil
+}
+
+func (s *notificationService) MarkAllNotificationsRead(ctx context.Context, username string) (int, error) {
+	if err := s.checkUser(ctx, username); err != nil {
+		return 0, err
+	}
+
+	marked, err := s.NotificationRepository.MarkAllNotificationsRead(ctx, username, time.Now())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error marking notifications read", slog.Any("error", err))
+		return 0, fmt.Errorf("Error marking notifications read, %w", err)
+	}
+	return marked, nil
+}
+
+// NotifyBidDecision сообщает автору итоговое решение по предложению
+func (s *notificationService) NotifyBidDecision(ctx context.Context, bid *model.Bid) error {
+	var notificationType model.NotificationType
+	var message string
+	switch bid.Status {
+	case model.BidStatusApproved:
+		notificationType = model.NotificationBidApproved
+		message = fmt.Sprintf("Your bid %q was approved", bid.Name)
+	case model.BidStatusRejected:
+		notificationType = model.NotificationBidRejected
+		message = fmt.Sprintf("Your bid %q was rejected", bid.Name)
+	default:
+		return nil
+	}
+
+	return s.create(ctx, withEmail(newNotification(bid.CreatorUsername, notificationType, bid.TenderID, &bid.ID, message), ""))
+}
+
+func (s *notificationService) NotifyBidFeedback(ctx context.Context, bid *model.Bid, feedback string) error {
+	message := fmt.Sprintf("New feedback on your bid %q", bid.Name)
+	return s.create(ctx, withEmail(newNotification(bid.CreatorUsername, model.NotificationBidFeedback, bid.TenderID, &bid.ID, message), feedback))
+}
+
+// NotifyTenderClosed сообщает о закрытии всем, чьи предложения видела организация тендера
+func (s *notificationService) NotifyTenderClosed(ctx context.Context, tender *model.Tender) error {
+	authors, err := s.NotificationRepository.GetTenderBidAuthors(ctx, tender.ID)
+	if err != nil {
+		return fmt.Errorf("Error getting tender bid authors, %w", err)
+	}
+
+	message := fmt.Sprintf("Tender %q you bid on was closed", tender.Name)
+	var notifications []model.Notification
+	for _, author := range authors {
+		notifications = append(notifications, newNotification(author, model.NotificationTenderClosed, tender.ID, nil, message))
+	}
+	return s.create(ctx, notifications...)
+}
+
+func (s *notificationService) create(ctx context.Context, notifications ...model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := s.NotificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		return fmt.Errorf("Error creating notifications, %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) checkUser(ctx context.Context, username string) error {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ErrUserNotFound
+		}
+		return fmt.Errorf("Error getting user, %w", err)
+	}
+	return nil
+}
+
+func newNotification(username string, notificationType model.NotificationType, tenderID string, bidID *string, message string) model.Notification {
+	return model.Notification{
+		ID:        uuid.NewString(),
+		Username:  username,
+		Type:      notificationType,
+		TenderID:  tenderID,
+		BidID:     bidID,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// withEmail ставит письмо в очередь вместе с уведомлением, отправляет его EmailDispatcher вне запроса
+func withEmail(notification model.Notification, feedback string) model.Notification {
+	notification.Email = &model.NotificationEmail{
+		NotificationID: notification.ID,
+		Feedback:       feedback,
+		Status:         model.NotificationEmailPending,
+		NextAttemptAt:  notification.CreatedAt,
+	}
+	return notification
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/notifier"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotificationRepository запоминает созданные уведомления, отдаёт заданные письма и запоминает сохранённые
+type fakeNotificationRepository struct {
+	repository.NotificationRepository
+	created    []model.Notification
+	dispatches []model.NotificationEmailDispatch
+	updated    []model.NotificationEmail
+}
+
+func (r *fakeNotificationRepository) CreateNotifications(ctx context.Context, notifications []model.Notification) error {
+	r.created = append(r.created, notifications...)
+	return nil
+}
+
+func (r *fakeNotificationRepository) ClaimNotificationEmails(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.NotificationEmailDispatch, error) {
+	dispatches := r.dispatches
+	r.dispatches = nil
+	return dispatches, nil
+}
+
+func (r *fakeNotificationRepository) UpdateNotificationEmail(ctx context.Context, email *model.NotificationEmail) error {
+	r.updated = append(r.updated, *email)
+	return nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	user *model.User
+}
+
+func (r *fakeUserRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	return r.user, nil
+}
+
+// fakeNotifier запоминает отправленные письма или возвращает заданную ошибку
+type fakeNotifier struct {
+	sent []notifier.Message
+	err  error
+}
+
+func (n *fakeNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func TestNotifyBidDecision(t *testing.T) {
+	bid := &model.Bid{ID: "bid-1", Name: "Поставка труб", TenderID: "tender-1", CreatorUsername: "ivanov", Status: model.BidStatusRejected}
+
+	newService := func() (*notificationService, *fakeNotificationRepository) {
+		repo := &fakeNotificationRepository{}
+		return &notificationService{repo, &fakeUserRepository{}, slog.Default()}, repo
+	}
+
+	t.Run("in-app with queued email", func(t *testing.T) {
+		s, repo := newService()
+
+		require.NoError(t, s.NotifyBidDecision(context.Background(), bid))
+		require.Len(t, repo.created, 1)
+		notification := repo.created[0]
+		assert.Equal(t, model.NotificationBidRejected, notification.Type)
+		assert.Equal(t, "ivanov", notification.Username)
+		// Письмо не отправляется в запросе, а ставится в очередь вместе с уведомлением
+		require.NotNil(t, notification.Email)
+		assert.Equal(t, notification.ID, notification.Email.NotificationID)
+		assert.Equal(t, model.NotificationEmailPending, notification.Email.Status)
+		assert.Equal(t, notification.CreatedAt, notification.Email.NextAttemptAt)
+	})
+
+	t.Run("pending approval is not a decision", func(t *testing.T) {
+		s, repo := newService()
+
+		published := *bid
+		published.Status = model.BidStatusPublished
+		require.NoError(t, s.NotifyBidDecision(context.Background(), &published))
+		assert.Empty(t, repo.created)
+	})
+}
+
+func TestNotifyBidFeedback(t *testing.T) {
+	bid := &model.Bid{ID: "bid-1", Name: "Поставка труб", TenderID: "tender-1", CreatorUsername: "ivanov"}
+	repo := &fakeNotificationRepository{}
+	s := &notificationService{repo, &fakeUserRepository{}, slog.Default()}
+
+	require.NoError(t, s.NotifyBidFeedback(context.Background(), bid, "Уточните сроки"))
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, model.NotificationBidFeedback, repo.created[0].Type)
+	require.NotNil(t, repo.created[0].Email)
+	assert.Equal(t, "Уточните сроки", repo.created[0].Email.Feedback)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, organization *model.CreateOrganizationRequest) (*model.Organization, error)
+	GetOrganizations(ctx context.Context, limit int, offset int) ([]model.Organization, error)
+	GetOrganizationById(ctx context.Context, id string) (*model.Organization, error)
+	EditOrganization(ctx context.Context, id string, username string, updateData model.UpdateOrganizationData) (*model.Organization, error)
+	AddOrganizationResponsible(ctx context.Context, id string, username string, responsibleUsername string) (*model.OrganizationResponsible, error)
+	RemoveOrganizationResponsible(ctx context.Context, id string, username string, responsibleUsername string) error
+}
+
+type organizationService struct {
+	organizationRepository repository.OrganizationRepository
+	userRepository         repository.UserRepository
+	logger                 *slog.Logger
+}
+
+func NewOrganizationService(organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository, logger *slog.Logger) OrganizationService {
+	return &organizationService{organizationRepository, userRepository, logger}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, organizationRequest *model.CreateOrganizationRequest) (*model.Organization, error) {
+	user, err := s.userRepository.GetUserByUsername(ctx, organizationRequest.CreatorUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	organization := &model.Organization{}
+
+	organization.Id = uuid.NewString()
+	organization.Name = organizationRequest.Name
+	organization.Description = organizationRequest.Description
+	organization.Type = organizationRequest.Type
+
+	organization, err = s.organizationRepository.CreateOrganization(ctx, organization, user.Id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error creating organization", slog.Any("error", err))
+		return nil, fmt.Errorf("Error creating organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) GetOrganizations(ctx context.Context, limit int, offset int) ([]model.Organization, error) {
+	organizations, err := s.organizationRepository.GetOrganizations(ctx, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting organizations", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (s *organizationService) GetOrganizationById(ctx context.Context, id string) (*model.Organization, error) {
+	organization, err := s.organizationRepository.GetOrganizationById(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrOrganizationNotFound) {
+			return nil, model.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("Error getting organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) EditOrganization(ctx context.Context, id string, username string, updateData model.UpdateOrganizationData) (*model.Organization, error) {
+	organization, err := s.checkResponsible(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateData.Name != nil {
+		if *updateData.Name != "" {
+			organization.Name = *updateData.Name
+		}
+	}
+
+	if updateData.Description != nil {
+		organization.Description = *updateData.Description
+	}
+
+	if updateData.Type != nil {
+		if *updateData.Type != "" {
+			organization.Type = model.OrganizationType(*updateData.Type)
+		}
+	}
+
+	organization, err = s.organizationRepository.UpdateOrganization(ctx, organization)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error updating organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrOrganizationNotFound) {
+			return nil, model.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("Error updating organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) AddOrganizationResponsible(ctx context.Context, id string, username string, responsibleUsername string) (*model.OrganizationResponsible, error) {
+	_, err := s.checkResponsible(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getResponsibleUser(ctx, responsibleUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	responsible := &model.OrganizationResponsible{}
+
+	responsible.ID = uuid.NewString()
+	responsible.OrganizationID = id
+	responsible.UserID = user.Id
+
+	responsible, err = s.organizationRepository.AddOrganizationResponsible(ctx, responsible)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error adding organization responsible", slog.Any("error", err))
+		if errors.Is(err, model.ErrResponsibleExists) {
+			return nil, model.ErrResponsibleExists
+		}
+		return nil, fmt.Errorf("Error adding organization responsible: %w", err)
+	}
+
+	return responsible, nil
+}
+
+func (s *organizationService) RemoveOrganizationResponsible(ctx context.Context, id string, username string, responsibleUsername string) error {
+	_, err := s.checkResponsible(ctx, id, username)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.getResponsibleUser(ctx, responsibleUsername)
+	if err != nil {
+		return err
+	}
+
+	err = s.organizationRepository.RemoveOrganizationResponsible(ctx, id, user.Id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error removing organization responsible", slog.Any("error", err))
+		if errors.Is(err, model.ErrResponsibleNotFound) || errors.Is(err, model.ErrLastResponsible) || errors.Is(err, model.ErrOrganizationNotFound) {
+			return err
+		}
+		return fmt.Errorf("Error removing organization responsible: %w", err)
+	}
+
+	return nil
+}
+
+func (s *organizationService) checkResponsible(ctx context.Context, id string, username string) (*model.Organization, error) {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	organization, err := s.organizationRepository.GetOrganizationById(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrOrganizationNotFound) {
+			return nil, model.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("Error getting organization: %w", err)
+	}
+
+	isResponsible, err := s.organizationRepository.IsUserResponsibleForOrganization(ctx, id, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user is responsible for organization", slog.Any("error", err))
+		return nil, fmt.Errorf("Error checking user is responsible for organization: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for organization", slog.String("username", username), slog.String("organizationID", id))
+		return nil, model.ErrForbidden
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) getResponsibleUser(ctx context.Context, responsibleUsername string) (*model.User, error) {
+	user, err := s.userRepository.GetUserByUsername(ctx, responsibleUsername)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting responsible user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrResponsibleNotFound
+		}
+		return nil, fmt.Errorf("Error getting responsible user: %w", err)
+	}
+
+	return user, nil
+}
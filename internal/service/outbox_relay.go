@@ -0,0 +1,84 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/publisher"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Сколько событий outbox публикуется за одну транзакцию
+const outboxBatchSize = 100
+
+type OutboxRelay interface {
+	Run(context.Context)
+	PublishPendingEvents(context.Context) (int, error)
+}
+
+type outboxRelay struct {
+	OutboxRepository repository.OutboxRepository
+	publisher        publisher.Publisher
+	// Получает события после фиксации публикации, его ошибки событие не повторяют
+	committed publisher.Publisher
+	interval  time.Duration
+	logger    *slog.Logger
+}
+
+func NewOutboxRelay(outboxRepository repository.OutboxRepository, publisher publisher.Publisher, committed publisher.Publisher, interval time.Duration, logger *slog.Logger) OutboxRelay {
+	return &outboxRelay{outboxRepository, publisher, committed, interval, logger}
+}
+
+// Run переносит события из outbox в publisher раз в interval, пока не отменён контекст
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := r.PublishPendingEvents(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.ErrorContext(ctx, "Error publishing outbox events", slog.Any("error", err))
+		}
+		if published > 0 {
+			r.logger.InfoContext(ctx, "Published outbox events", slog.Int("count", published))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *outboxRelay) PublishPendingEvents(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		var accepted []model.Event
+		count, err := r.OutboxRepository.PublishPendingEvents(ctx, outboxBatchSize, func(ctx context.Context, event model.Event) error {
+			if err := r.publisher.Publish(ctx, event); err != nil {
+				return err
+			}
+			accepted = append(accepted, event)
+			return nil
+		})
+		published += count
+
+		// Поток событий читает только опубликованные события, поэтому подписчиков будим после фиксации
+		for _, event := range accepted[:count] {
+			if err := r.committed.Publish(ctx, event); err != nil {
+				r.logger.ErrorContext(ctx, "Error notifying about published event", slog.Any("error", err))
+			}
+		}
+		if err != nil {
+			return published, fmt.Errorf("Error publishing outbox events, %w", err)
+		}
+
+		// Неполная пачка - очередь разобрана
+		if count < outboxBatchSize {
+			return published, nil
+		}
+	}
+}
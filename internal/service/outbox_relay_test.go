@@ -0,0 +1,140 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/publisher"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOutboxRepository повторяет контракт PublishPendingEvents: пачка по порядку id, остановка на первой ошибке
+type fakeOutboxRepository struct {
+	repository.OutboxRepository
+	pending []model.Event
+	batches int
+}
+
+func (r *fakeOutboxRepository) PublishPendingEvents(ctx context.Context, limit int, publish func(context.Context, model.Event) error) (int, error) {
+	r.batches++
+	batch := r.pending
+	if len(batch) > limit {
+		batch = batch[:limit]
+	}
+
+	published := 0
+	for _, event := range batch {
+		if err := publish(ctx, event); err != nil {
+			r.pending = r.pending[published:]
+			return published, err
+		}
+		published++
+	}
+	r.pending = r.pending[published:]
+	return published, nil
+}
+
+// failingPublisher отказывает на событии failID, пока не исчерпает failures
+type failingPublisher struct {
+	failID   int64
+	failures int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, event model.Event) error {
+	if event.ID == p.failID && p.failures > 0 {
+		p.failures--
+		return errors.New("publish failed")
+	}
+	return nil
+}
+
+func outboxEvents(count int) []model.Event {
+	events := make([]model.Event, count)
+	for i := range events {
+		events[i] = model.Event{ID: int64(i + 1), Type: model.EventBidSubmitted, TenderID: "tender-1"}
+	}
+	return events
+}
+
+func eventIDs(events []model.Event) []int64 {
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func newTestOutboxRelay(repo *fakeOutboxRepository, p publisher.Publisher, committed publisher.Publisher) *outboxRelay {
+	return &outboxRelay{OutboxRepository: repo, publisher: p, committed: committed, interval: time.Second, logger: slog.Default()}
+}
+
+func TestOutboxRelayPublishPendingEvents(t *testing.T) {
+	t.Run("all batches", func(t *testing.T) {
+		repo := &fakeOutboxRepository{pending: outboxEvents(outboxBatchSize + 1)}
+		memory := publisher.NewMemoryPublisher()
+		committed := publisher.NewMemoryPublisher()
+
+		published, err := newTestOutboxRelay(repo, memory, committed).PublishPendingEvents(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, outboxBatchSize+1, published)
+		assert.Equal(t, 2, repo.batches)
+		assert.Equal(t, eventIDs(outboxEvents(outboxBatchSize+1)), eventIDs(memory.Events()))
+		assert.Equal(t, eventIDs(memory.Events()), eventIDs(committed.Events()))
+		assert.Empty(t, repo.pending)
+	})
+
+	t.Run("empty outbox", func(t *testing.T) {
+		repo := &fakeOutboxRepository{}
+		memory := publisher.NewMemoryPublisher()
+
+		published, err := newTestOutboxRelay(repo, memory, publisher.NewMemoryPublisher()).PublishPendingEvents(context.Background())
+		assert.NoError(t, err)
+		assert.Zero(t, published)
+		assert.Equal(t, 1, repo.batches)
+		assert.Empty(t, memory.Events())
+	})
+
+	t.Run("stops on first error and resumes in order", func(t *testing.T) {
+		repo := &fakeOutboxRepository{pending: outboxEvents(5)}
+		memory := publisher.NewMemoryPublisher()
+		committed := publisher.NewMemoryPublisher()
+		relay := newTestOutboxRelay(repo, publisher.NewMultiPublisher(&failingPublisher{failID: 3, failures: 1}, memory), committed)
+
+		published, err := relay.PublishPendingEvents(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []int64{1, 2}, eventIDs(memory.Events()))
+		// Сигнал после фиксации получают только принятые события
+		assert.Equal(t, []int64{1, 2}, eventIDs(committed.Events()))
+		// Событие с ошибкой и всё после него остаются в очереди
+		assert.Equal(t, []int64{3, 4, 5}, eventIDs(repo.pending))
+
+		published, err = relay.PublishPendingEvents(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 3, published)
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, eventIDs(memory.Events()))
+	})
+
+	t.Run("multi publisher stops at failing publisher", func(t *testing.T) {
+		repo := &fakeOutboxRepository{pending: outboxEvents(1)}
+		before := publisher.NewMemoryPublisher()
+		after := publisher.NewMemoryPublisher()
+		relay := newTestOutboxRelay(repo, publisher.NewMultiPublisher(before, &failingPublisher{failID: 1, failures: 1}, after), publisher.NewMemoryPublisher())
+
+		_, err := relay.PublishPendingEvents(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, []int64{1}, eventIDs(before.Events()))
+		assert.Empty(t, after.Events())
+
+		// Повтор доходит до всех publisher, стоящие до упавшего получают событие повторно
+		_, err = relay.PublishPendingEvents(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 1}, eventIDs(before.Events()))
+		assert.Equal(t, []int64{1}, eventIDs(after.Events()))
+	})
+}
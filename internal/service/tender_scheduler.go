@@ -0,0 +1,116 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Сколько просроченных тендеров закрывается за один запрос к базе
+const expiredTendersBatchSize = 100
+
+type TenderScheduler interface {
+	Run(context.Context)
+	CloseExpiredTenders(context.Context) (int, error)
+	FinishAuctions(context.Context) (int, error)
+}
+
+type tenderScheduler struct {
+	TenderRepository    repository.TenderRepository
+	notificationService NotificationService
+	interval            time.Duration
+	logger              *slog.Logger
+}
+
+func NewTenderScheduler(tenderRepository repository.TenderRepository, notificationService NotificationService, interval time.Duration, logger *slog.Logger) TenderScheduler {
+	return &tenderScheduler{tenderRepository, notificationService, interval, logger}
+}
+
+// Run раз в interval подводит итоги редукционов и закрывает просроченные тендеры, пока не отменён контекст
+func (s *tenderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		// Итоги подводятся до закрытия: закрытый тендер в редукцион уже не попадёт
+		finished, err := s.FinishAuctions(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "Error finishing auctions", slog.Any("error", err))
+		}
+		if finished > 0 {
+			s.logger.InfoContext(ctx, "Finished auctions", slog.Int("count", finished))
+		}
+
+		closed, err := s.CloseExpiredTenders(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "Error closing expired tenders", slog.Any("error", err))
+		}
+		if closed > 0 {
+			s.logger.InfoContext(ctx, "Closed expired tenders", slog.Int("count", closed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *tenderScheduler) CloseExpiredTenders(ctx context.Context) (int, error) {
+	closed := 0
+	for {
+		tenders, err := s.TenderRepository.GetExpiredTenders(ctx, time.Now(), expiredTendersBatchSize)
+		if err != nil {
+			return closed, fmt.Errorf("Error getting expired tenders, %w", err)
+		}
+
+		conflicts := 0
+		for i := range tenders {
+			tender := tenders[i]
+			tender.Status = model.TenderStatusClosed
+
+			// Закрытие идёт через обычное обновление, чтобы в истории появилась версия
+			updated, err := s.TenderRepository.UpdateTender(ctx, &tender)
+			if err != nil {
+				// Тендер успели изменить - он попадёт в следующую выборку, если всё ещё просрочен
+				if errors.Is(err, model.ErrVersionConflict) {
+					conflicts++
+					continue
+				}
+				return closed, fmt.Errorf("Error closing tender %s, %w", tender.ID, err)
+			}
+			closed++
+
+			if err := s.notificationService.NotifyTenderClosed(ctx, updated); err != nil {
+				s.logger.ErrorContext(ctx, "Error notifying about closed tender", slog.Any("error", err))
+			}
+		}
+
+		if len(tenders) < expiredTendersBatchSize || conflicts == len(tenders) {
+			return closed, nil
+		}
+	}
+}
+
+// FinishAuctions фиксирует итоги завершившихся редукционов: победитель предлагается ответственным для решения
+func (s *tenderScheduler) FinishAuctions(ctx context.Context) (int, error) {
+	results, err := s.TenderRepository.FinishAuctions(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("Error finishing auctions, %w", err)
+	}
+
+	for _, result := range results {
+		if result.BidID == nil {
+			s.logger.InfoContext(ctx, "Auction finished without bids", slog.String("tenderID", result.TenderID))
+			continue
+		}
+		s.logger.InfoContext(ctx, "Auction winner proposed for decision", slog.String("tenderID", result.TenderID), slog.String("bidID", *result.BidID))
+	}
+
+	return len(results), nil
+}
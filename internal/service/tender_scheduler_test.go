@@ -0,0 +1,140 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExpiredTenderRepository отдаёт просроченные тендеры пачками и отклоняет обновление тендеров из conflicts
+type fakeExpiredTenderRepository struct {
+	repository.TenderRepository
+	batches   [][]model.Tender
+	conflicts map[string]bool
+	requests  int
+	closed    []string
+}
+
+func (r *fakeExpiredTenderRepository) GetExpiredTenders(ctx context.Context, now time.Time, limit int) ([]model.Tender, error) {
+	r.requests++
+	if len(r.batches) == 0 {
+		return nil, nil
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+func (r *fakeExpiredTenderRepository) UpdateTender(ctx context.Context, tender *model.Tender) (*model.Tender, error) {
+	if r.conflicts[tender.ID] {
+		return nil, model.ErrVersionConflict
+	}
+	if tender.ID == "broken" {
+		return nil, errors.New("connection reset")
+	}
+	r.closed = append(r.closed, tender.ID)
+	updated := *tender
+	updated.Version++
+	return &updated, nil
+}
+
+// fakeClosedTenderNotifier запоминает тендеры, о закрытии которых сообщили
+type fakeClosedTenderNotifier struct {
+	NotificationService
+	notified []string
+}
+
+func (n *fakeClosedTenderNotifier) NotifyTenderClosed(ctx context.Context, tender *model.Tender) error {
+	n.notified = append(n.notified, tender.ID)
+	return nil
+}
+
+func expiredTenders(prefix string, count int) []model.Tender {
+	tenders := make([]model.Tender, count)
+	for i := range tenders {
+		tenders[i] = model.Tender{ID: fmt.Sprintf("%s-%d", prefix, i), Status: model.TenderStatusPublished, Version: 1}
+	}
+	return tenders
+}
+
+func TestCloseExpiredTenders(t *testing.T) {
+	newScheduler := func(repo *fakeExpiredTenderRepository) (*tenderScheduler, *fakeClosedTenderNotifier) {
+		notifier := &fakeClosedTenderNotifier{}
+		return &tenderScheduler{TenderRepository: repo, notificationService: notifier, interval: time.Minute, logger: slog.Default()}, notifier
+	}
+
+	t.Run("partial batch", func(t *testing.T) {
+		repo := &fakeExpiredTenderRepository{batches: [][]model.Tender{expiredTenders("a", 3)}}
+		s, notifier := newScheduler(repo)
+
+		closed, err := s.CloseExpiredTenders(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, closed)
+		assert.Equal(t, 1, repo.requests)
+		assert.Equal(t, []string{"a-0", "a-1", "a-2"}, notifier.notified)
+	})
+
+	t.Run("full batch requests next batch", func(t *testing.T) {
+		repo := &fakeExpiredTenderRepository{batches: [][]model.Tender{
+			expiredTenders("a", expiredTendersBatchSize),
+			expiredTenders("b", 2),
+		}}
+		s, notifier := newScheduler(repo)
+
+		closed, err := s.CloseExpiredTenders(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expiredTendersBatchSize+2, closed)
+		assert.Equal(t, 2, repo.requests)
+		assert.Len(t, notifier.notified, expiredTendersBatchSize+2)
+	})
+
+	t.Run("full batch of conflicts stops", func(t *testing.T) {
+		batch := expiredTenders("a", expiredTendersBatchSize)
+		conflicts := map[string]bool{}
+		for _, tender := range batch {
+			conflicts[tender.ID] = true
+		}
+		// Без остановки те же тендеры выбирались бы снова и снова
+		repo := &fakeExpiredTenderRepository{batches: [][]model.Tender{batch, batch}, conflicts: conflicts}
+		s, notifier := newScheduler(repo)
+
+		closed, err := s.CloseExpiredTenders(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, closed)
+		assert.Equal(t, 1, repo.requests)
+		assert.Empty(t, notifier.notified)
+	})
+
+	t.Run("conflicts are skipped", func(t *testing.T) {
+		repo := &fakeExpiredTenderRepository{
+			batches:   [][]model.Tender{expiredTenders("a", 3)},
+			conflicts: map[string]bool{"a-1": true},
+		}
+		s, notifier := newScheduler(repo)
+
+		closed, err := s.CloseExpiredTenders(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, closed)
+		assert.Equal(t, []string{"a-0", "a-2"}, notifier.notified)
+	})
+
+	t.Run("update error stops with closed count", func(t *testing.T) {
+		batch := expiredTenders("a", 3)
+		batch[1].ID = "broken"
+		repo := &fakeExpiredTenderRepository{batches: [][]model.Tender{batch}}
+		s, _ := newScheduler(repo)
+
+		closed, err := s.CloseExpiredTenders(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 1, closed)
+		assert.Equal(t, []string{"a-0"}, repo.closed)
+	})
+}
@@ -5,29 +5,39 @@ import (
 	"Backend-trainee-assignment-autumn-2024/internal/repository"
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TenderService interface {
 	CreateTender(context.Context, *model.CreateTenderRequest) (*model.Tender, error)
-	GetTenders(context.Context, int, int, []model.TenderServiceType) ([]model.Tender, error)
+	GetTenders(context.Context, int, int, *model.Cursor, model.TenderFilter, string) ([]model.Tender, error)
+	SearchTenders(context.Context, string, int, int, model.TenderFilter, string) ([]model.TenderSearchResult, error)
 	GetTenderById(context.Context, string) (*model.Tender, error)
-	GetCurrentUserTenders(context.Context, int, int, string) ([]model.Tender, error)
-	GetTenderStatus(context.Context, string) (string, error)
-	UpdateTenderStatus(context.Context, string, string, string) (*model.Tender, error)
-	EditTender(context.Context, string, string, model.UpdateData) (*model.Tender, error)
-	RollbackTenderVersion(context.Context, string, int) (*model.Tender, error)
+	GetCurrentUserTenders(context.Context, int, int, *model.Cursor, string) ([]model.Tender, error)
+	GetTenderStatus(context.Context, string, string) (string, error)
+	UpdateTenderStatus(context.Context, string, string, string, int) (*model.Tender, error)
+	EditTender(context.Context, string, string, model.UpdateData, int) (*model.Tender, error)
+	RollbackTenderVersion(context.Context, string, string, int) (*model.Tender, error)
+	GetTenderVersions(context.Context, string, string, int, int) ([]model.Tender, error)
+	GetTenderVersion(context.Context, string, string, int) (*model.Tender, error)
+	GetTenderDiff(context.Context, string, string, int, int) (*model.VersionDiff, error)
+	SetTenderCriteria(context.Context, string, string, []model.CriterionInput) ([]model.TenderCriterion, error)
+	GetTenderCriteria(context.Context, string, string) ([]model.TenderCriterion, error)
+	GetAuction(context.Context, string, string) (*model.Auction, error)
 }
 
 type tenderService struct {
 	TenderRepository       repository.TenderRepository
 	OrganizationRepository repository.OrganizationRepository
+	statusTransitions      model.TenderStatusTransitions
+	notificationService    NotificationService
 	logger                 *slog.Logger
 }
 
-func NewTenderService(tenderRepository repository.TenderRepository, OrganizationRepository repository.OrganizationRepository, logger *slog.Logger) TenderService {
-	return &tenderService{tenderRepository, OrganizationRepository, logger}
+func NewTenderService(tenderRepository repository.TenderRepository, OrganizationRepository repository.OrganizationRepository, statusTransitions model.TenderStatusTransitions, notificationService NotificationService, logger *slog.Logger) TenderService {
+	return &tenderService{tenderRepository, OrganizationRepository, statusTransitions, notificationService, logger}
 }
 
 func (s *tenderService) CreateTender(ctx context.Context, createTenderRequest *model.CreateTenderRequest) (*model.Tender, error) {
@@ -54,9 +64,35 @@ func (s *tenderService) CreateTender(ctx context.Context, createTenderRequest *m
 	tender.ServiceType = createTenderRequest.ServiceType
 	tender.OrganizationID = createTenderRequest.OrganizationID
 	tender.CreatorUsername = createTenderRequest.CreatorUsername
+	tender.BudgetMin = createTenderRequest.BudgetMin
+	tender.BudgetMax = createTenderRequest.BudgetMax
+	tender.BudgetCurrency = createTenderRequest.BudgetCurrency
+	tender.Deadline = createTenderRequest.Deadline
+	tender.Auction = createTenderRequest.Auction
+	tender.AuctionEndsAt = createTenderRequest.AuctionEndsAt
 	tender.Version = 1
 	tender.Status = model.TenderStatusCreated
 
+	if err := tender.CheckBudget(); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid tender budget", slog.Any("error", err))
+		return nil, err
+	}
+
+	if tender.IsExpired(time.Now()) {
+		s.logger.ErrorContext(ctx, "Tender deadline is in the past", slog.Time("deadline", *tender.Deadline))
+		return nil, model.ErrInvalidDeadline
+	}
+
+	if err := tender.CheckAuction(); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid tender auction", slog.Any("error", err))
+		return nil, err
+	}
+
+	if tender.Auction && !tender.IsAuctionOpen(time.Now()) {
+		s.logger.ErrorContext(ctx, "Tender auction window is invalid", slog.Time("auctionEndsAt", *tender.AuctionEndsAt))
+		return nil, model.ErrInvalidAuction
+	}
+
 	tender, err = s.TenderRepository.CreateTender(ctx, tender)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error creating tender", slog.Any("error", err))
@@ -66,9 +102,9 @@ func (s *tenderService) CreateTender(ctx context.Context, createTenderRequest *m
 	return tender, nil
 }
 
-func (s *tenderService) GetTenders(ctx context.Context, limit int, offset int, serviceTypes []model.TenderServiceType) ([]model.Tender, error) {
+func (s *tenderService) GetTenders(ctx context.Context, limit int, offset int, cursor *model.Cursor, filter model.TenderFilter, username string) ([]model.Tender, error) {
 
-	tenders, err := s.TenderRepository.GetTenders(ctx, limit, offset, serviceTypes)
+	tenders, err := s.TenderRepository.GetTenders(ctx, limit, offset, cursor, filter, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		return nil, err
@@ -77,6 +113,17 @@ func (s *tenderService) GetTenders(ctx context.Context, limit int, offset int, s
 	return tenders, nil
 }
 
+func (s *tenderService) SearchTenders(ctx context.Context, query string, limit int, offset int, filter model.TenderFilter, username string) ([]model.TenderSearchResult, error) {
+
+	results, err := s.TenderRepository.SearchTenders(ctx, query, limit, offset, filter, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error searching tenders", slog.Any("error", err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (s *tenderService) GetTenderById(ctx context.Context, id string) (*model.Tender, error) {
 	tender, err := s.TenderRepository.GetTenderById(ctx, id)
 	if err != nil {
@@ -89,9 +136,9 @@ func (s *tenderService) GetTenderById(ctx context.Context, id string) (*model.Te
 	return tender, nil
 }
 
-func (s *tenderService) GetCurrentUserTenders(ctx context.Context, limit int, offset int, username string) ([]model.Tender, error) {
+func (s *tenderService) GetCurrentUserTenders(ctx context.Context, limit int, offset int, cursor *model.Cursor, username string) ([]model.Tender, error) {
 
-	tenders, err := s.TenderRepository.GetTenderByUsername(ctx, limit, offset, username)
+	tenders, err := s.TenderRepository.GetTenderByUsername(ctx, limit, offset, cursor, username)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error getting tenders", slog.Any("error", err))
 		if err == model.ErrUserNotFound {
@@ -103,20 +150,17 @@ func (s *tenderService) GetCurrentUserTenders(ctx context.Context, limit int, of
 	return tenders, nil
 }
 
-func (s *tenderService) GetTenderStatus(ctx context.Context, id string) (string, error) {
+func (s *tenderService) GetTenderStatus(ctx context.Context, id string, username string) (string, error) {
 
-	tender, err := s.TenderRepository.GetTenderById(ctx, id)
+	tender, err := s.getVisibleTender(ctx, id, username)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Error getting tender status", slog.Any("error", err))
-		if err == model.ErrTenderNotFound {
-			return "", model.ErrTenderNotFound
-		}
 		return "", err
 	}
+
 	return string(tender.Status), nil
 }
 
-func (s *tenderService) UpdateTenderStatus(ctx context.Context, id string, username string, status string) (*model.Tender, error) {
+func (s *tenderService) UpdateTenderStatus(ctx context.Context, id string, username string, status string, expectedVersion int) (*model.Tender, error) {
 
 	isResponsible, err := s.TenderRepository.IsUserResponsibleForTender(ctx, id, username)
 	if err != nil {
@@ -141,8 +185,17 @@ func (s *tenderService) UpdateTenderStatus(ctx context.Context, id string, usern
 		return nil, err
 	}
 
+	if expectedVersion != 0 && tender.Version != expectedVersion {
+		s.logger.ErrorContext(ctx, "Tender version mismatch", slog.Int("expected", expectedVersion), slog.Int("actual", tender.Version))
+		return nil, model.ErrVersionConflict
+	}
+
 	if tender.Status == model.TenderStatus(status) {
-		s.logger.ErrorContext(ctx, "Status is the same", slog.String("status", status))
+		return tender, nil
+	}
+
+	if err := s.statusTransitions.Check(tender.Status, model.TenderStatus(status)); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid tender status transition", slog.Any("error", err))
 		return nil, err
 	}
 
@@ -153,10 +206,16 @@ func (s *tenderService) UpdateTenderStatus(ctx context.Context, id string, usern
 		return nil, err
 	}
 
+	if tender.Status == model.TenderStatusClosed {
+		if err := s.notificationService.NotifyTenderClosed(ctx, tender); err != nil {
+			s.logger.ErrorContext(ctx, "Error notifying about closed tender", slog.Any("error", err))
+		}
+	}
+
 	return tender, nil
 }
 
-func (s *tenderService) EditTender(ctx context.Context, id string, username string, updateData model.UpdateData) (*model.Tender, error) {
+func (s *tenderService) EditTender(ctx context.Context, id string, username string, updateData model.UpdateData, expectedVersion int) (*model.Tender, error) {
 
 	tender, err := s.TenderRepository.GetTenderById(ctx, id)
 	if err != nil {
@@ -185,6 +244,12 @@ func (s *tenderService) EditTender(ctx context.Context, id string, username stri
 		s.logger.ErrorContext(ctx, "User is not the creator of the tender", slog.String("username", username), slog.String("tenderID", id))
 		return nil, model.ErrForbidden
 	}
+
+	if expectedVersion != 0 && tender.Version != expectedVersion {
+		s.logger.ErrorContext(ctx, "Tender version mismatch", slog.Int("expected", expectedVersion), slog.Int("actual", tender.Version))
+		return nil, model.ErrVersionConflict
+	}
+
 	if updateData.Name != nil {
 		if *updateData.Name != "" {
 			tender.Name = *updateData.Name
@@ -203,6 +268,42 @@ func (s *tenderService) EditTender(ctx context.Context, id string, username stri
 		}
 	}
 
+	if updateData.BudgetMin != nil {
+		tender.BudgetMin = updateData.BudgetMin
+	}
+
+	if updateData.BudgetMax != nil {
+		tender.BudgetMax = updateData.BudgetMax
+	}
+
+	if updateData.BudgetCurrency != nil {
+		// Смена валюты сделала бы уже поданные цены редукциона несравнимыми
+		if tender.Auction && tender.BudgetCurrency != nil && *updateData.BudgetCurrency != *tender.BudgetCurrency {
+			s.logger.ErrorContext(ctx, "Cannot change auction currency", slog.String("tenderID", tender.ID))
+			return nil, model.ErrInvalidAuction
+		}
+		tender.BudgetCurrency = updateData.BudgetCurrency
+	}
+
+	// Дедлайн применяется до проверки редукциона: торги должны завершиться раньше нового дедлайна
+	if updateData.Deadline != nil {
+		if !updateData.Deadline.After(time.Now()) {
+			s.logger.ErrorContext(ctx, "Tender deadline is in the past", slog.Time("deadline", *updateData.Deadline))
+			return nil, model.ErrInvalidDeadline
+		}
+		tender.Deadline = updateData.Deadline
+	}
+
+	if err := tender.CheckBudget(); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid tender budget", slog.Any("error", err))
+		return nil, err
+	}
+
+	if err := tender.CheckAuction(); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid tender auction", slog.Any("error", err))
+		return nil, err
+	}
+
 	tender, err = s.TenderRepository.UpdateTender(ctx, tender)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error updating tender", slog.Any("error", err))
@@ -212,9 +313,43 @@ func (s *tenderService) EditTender(ctx context.Context, id string, username stri
 	return tender, nil
 }
 
-func (s *tenderService) RollbackTenderVersion(ctx context.Context, id string, version int) (*model.Tender, error) {
+func (s *tenderService) RollbackTenderVersion(ctx context.Context, id string, username string, version int) (*model.Tender, error) {
 
-	tender, err := s.TenderRepository.RollbackTenderVersion(ctx, id, version)
+	isResponsible, err := s.TenderRepository.IsUserResponsibleForTender(ctx, id, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user is responsible for tender", slog.Any("error", err))
+		if err == model.ErrUserNotFound {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for the tender", slog.String("username", username), slog.String("tenderID", id))
+		return nil, model.ErrForbidden
+	}
+
+	// Восстановленная версия проходит те же проверки бюджета, дедлайна и редукциона, что и CreateTender:
+	// старый дедлайн мог пройти или оказаться раньше окончания торгов
+	tender, err := s.TenderRepository.RollbackTenderVersion(ctx, id, version, func(current *model.Tender, restored *model.Tender) error {
+		if current.Auction && current.BudgetCurrency != nil && (restored.BudgetCurrency == nil || *restored.BudgetCurrency != *current.BudgetCurrency) {
+			return model.ErrInvalidAuction
+		}
+		if err := restored.CheckBudget(); err != nil {
+			return err
+		}
+		now := time.Now()
+		if restored.IsExpired(now) {
+			return model.ErrInvalidDeadline
+		}
+		if err := restored.CheckAuction(); err != nil {
+			return err
+		}
+		if restored.Auction && !restored.IsAuctionOpen(now) {
+			return model.ErrInvalidAuction
+		}
+		return nil
+	})
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error rolling back tender version", slog.Any("error", err))
 		if err == model.ErrVersionNotFound {
@@ -223,7 +358,163 @@ func (s *tenderService) RollbackTenderVersion(ctx context.Context, id string, ve
 		if err == model.ErrTenderNotFound {
 			return nil, model.ErrTenderNotFound
 		}
+		if err == model.ErrVersionConflict {
+			return nil, model.ErrVersionConflict
+		}
+		return nil, err
+	}
+	return tender, nil
+}
+
+func (s *tenderService) GetTenderVersions(ctx context.Context, id string, username string, limit int, offset int) ([]model.Tender, error) {
+
+	_, err := s.getVisibleTender(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.TenderRepository.GetTenderVersions(ctx, id, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender versions", slog.Any("error", err))
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *tenderService) GetTenderVersion(ctx context.Context, id string, username string, version int) (*model.Tender, error) {
+
+	_, err := s.getVisibleTender(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.TenderRepository.GetTenderVersion(ctx, id, version)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender version", slog.Any("error", err))
+		return nil, err
+	}
+
+	return tender, nil
+}
+
+func (s *tenderService) GetTenderDiff(ctx context.Context, id string, username string, from int, to int) (*model.VersionDiff, error) {
+
+	fromTender, err := s.GetTenderVersion(ctx, id, username, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTender, err := s.TenderRepository.GetTenderVersion(ctx, id, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender version", slog.Any("error", err))
+		return nil, err
+	}
+
+	return model.DiffTenders(fromTender, toTender), nil
+}
+
+func (s *tenderService) SetTenderCriteria(ctx context.Context, id string, username string, input []model.CriterionInput) ([]model.TenderCriterion, error) {
+
+	_, err := s.TenderRepository.GetTenderById(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if err == model.ErrTenderNotFound {
+			return nil, model.ErrTenderNotFound
+		}
+		return nil, err
+	}
+
+	isResponsible, err := s.TenderRepository.IsUserResponsibleForTender(ctx, id, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user is responsible for tender", slog.Any("error", err))
+		if err == model.ErrUserNotFound {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for the tender", slog.String("username", username), slog.String("tenderID", id))
+		return nil, model.ErrForbidden
+	}
+
+	criteria := make([]model.TenderCriterion, len(input))
+	for i, criterion := range input {
+		criteria[i] = model.TenderCriterion{
+			ID:        uuid.NewString(),
+			TenderID:  id,
+			Name:      criterion.Name,
+			Weight:    criterion.Weight,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	saved, err := s.TenderRepository.SetTenderCriteria(ctx, id, criteria)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error saving tender criteria", slog.Any("error", err))
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *tenderService) GetTenderCriteria(ctx context.Context, id string, username string) ([]model.TenderCriterion, error) {
+
+	_, err := s.getVisibleTender(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.TenderRepository.GetTenderCriteria(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender criteria", slog.Any("error", err))
+		return nil, err
+	}
+
+	return criteria, nil
+}
+
+func (s *tenderService) GetAuction(ctx context.Context, id string, username string) (*model.Auction, error) {
+
+	_, err := s.getVisibleTender(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	auction, err := s.TenderRepository.GetAuction(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting auction", slog.Any("error", err))
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// Опубликованный тендер виден всем, остальные - только ответственным за организацию
+func (s *tenderService) getVisibleTender(ctx context.Context, id string, username string) (*model.Tender, error) {
+
+	tender, err := s.TenderRepository.GetTenderById(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting tender", slog.Any("error", err))
+		if err == model.ErrTenderNotFound {
+			return nil, model.ErrTenderNotFound
+		}
 		return nil, err
 	}
+
+	if tender.Status != model.TenderStatusPublished {
+		isResponsible, err := s.TenderRepository.IsUserResponsibleForTender(ctx, id, username)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error checking user is responsible for tender", slog.Any("error", err))
+			return nil, err
+		}
+
+		if !isResponsible {
+			s.logger.ErrorContext(ctx, "User cannot see the tender", slog.String("username", username), slog.String("tenderID", id))
+			return nil, model.ErrForbidden
+		}
+	}
+
 	return tender, nil
 }
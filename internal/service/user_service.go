@@ -0,0 +1,152 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/pkg/utils"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	CreateUser(ctx context.Context, user *model.CreateUserRequest) (*model.User, error)
+	GetUsers(ctx context.Context, limit int, offset int) ([]model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	EditUser(ctx context.Context, username string, updateData model.UpdateUserData) (*model.User, error)
+	GetCurrentUserOrganizations(ctx context.Context, limit int, offset int, username string) ([]model.Organization, error)
+}
+
+type userService struct {
+	userRepository repository.UserRepository
+	logger         *slog.Logger
+}
+
+func NewUserService(userRepository repository.UserRepository, logger *slog.Logger) UserService {
+	return &userService{userRepository, logger}
+}
+
+func (s *userService) CreateUser(ctx context.Context, userRequest *model.CreateUserRequest) (*model.User, error) {
+	user := &model.User{}
+
+	user.Id = uuid.NewString()
+	user.Username = userRequest.Username
+	user.First_name = userRequest.FirstName
+	user.Last_name = userRequest.LastName
+	if userRequest.Email != "" {
+		user.Email = &userRequest.Email
+	}
+	user.Language = model.LanguageRu
+	if userRequest.Language != "" {
+		user.Language = model.Language(userRequest.Language)
+	}
+	user.EmailNotifications = true
+
+	passwordHash, err := utils.HashPassword(userRequest.Password)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error hashing password", slog.Any("error", err))
+		return nil, fmt.Errorf("Error hashing password: %w", err)
+	}
+	user.PasswordHash = passwordHash
+
+	user, err = s.userRepository.CreateUser(ctx, user)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error creating user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserExists) {
+			return nil, model.ErrUserExists
+		}
+		return nil, fmt.Errorf("Error creating user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) GetUsers(ctx context.Context, limit int, offset int) ([]model.User, error) {
+	users, err := s.userRepository.GetUsers(ctx, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting users", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (s *userService) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	user, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error getting user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) EditUser(ctx context.Context, username string, updateData model.UpdateUserData) (*model.User, error) {
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateData.FirstName != nil {
+		user.First_name = *updateData.FirstName
+	}
+
+	if updateData.LastName != nil {
+		user.Last_name = *updateData.LastName
+	}
+
+	if updateData.Email != nil {
+		user.Email = nil
+		if *updateData.Email != "" {
+			user.Email = updateData.Email
+		}
+	}
+
+	if updateData.Language != nil {
+		user.Language = model.Language(*updateData.Language)
+	}
+
+	if updateData.EmailNotifications != nil {
+		user.EmailNotifications = *updateData.EmailNotifications
+	}
+
+	if updateData.Password != nil {
+		user.PasswordHash, err = utils.HashPassword(*updateData.Password)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Error hashing password", slog.Any("error", err))
+			return nil, fmt.Errorf("Error hashing password: %w", err)
+		}
+	}
+
+	user, err = s.userRepository.UpdateUser(ctx, user)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error updating user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Error updating user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) GetCurrentUserOrganizations(ctx context.Context, limit int, offset int, username string) ([]model.Organization, error) {
+	_, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := s.userRepository.GetResponsibleOrganizations(ctx, username, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user organizations", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting user organizations: %w", err)
+	}
+
+	return organizations, nil
+}
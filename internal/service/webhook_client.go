@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var errWebhookAddressNotAllowed = errors.New("webhook address is not public")
+
+// Общее адресное пространство провайдеров (RFC 6598), в облаках там бывают служебные сервисы
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Служебные IPv4-сети, которых нет среди проверок net.IP: "эта сеть", протокольные назначения и стенды тестирования
+var reservedIPv4Networks = []*net.IPNet{
+	sharedAddressSpace,
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(192, 0, 0, 0), Mask: net.CIDRMask(24, 32)},
+	{IP: net.IPv4(198, 18, 0, 0), Mask: net.CIDRMask(15, 32)},
+}
+
+// Префикс NAT64 (RFC 6052): шлюз транслирует такой адрес в IPv4 из последних четырёх байт
+var nat64Prefix = &net.IPNet{IP: net.ParseIP("64:ff9b::"), Mask: net.CIDRMask(96, 128)}
+
+// NewWebhookClient возвращает клиент для адресов, заданных организациями. Внутренние адреса
+// проверяются при установке соединения, поэтому их не обойти ни DNS-записью, ни редиректом
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: denyInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Через прокси проверялся бы адрес прокси, а не получателя
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// Редирект считается ответом получателя: 3xx - неуспешная доставка
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func denyInternalAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddressNotAllowed, host)
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	// Через NAT64 доступен любой IPv4, поэтому проверяется адрес после трансляции
+	if nat64Prefix.Contains(ip) {
+		ip = net.IP(ip[12:16])
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	if ip.To4() != nil {
+		for _, network := range reservedIPv4Networks {
+			if network.Contains(ip) {
+				return false
+			}
+		}
+	}
+	return true
+}
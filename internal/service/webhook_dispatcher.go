@@ -0,0 +1,218 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Сколько доставок берётся в работу за один запрос к базе
+	webhookBatchSize = 20
+	// Потолок паузы между повторами
+	maxWebhookBackoff = 6 * time.Hour
+	// Заголовок с HMAC-SHA256 тела запроса в hex, ключ - секрет подписки
+	WebhookSignatureHeader = "X-Webhook-Signature-256"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// WebhookDispatcher раскладывает события из outbox по подпискам и отправляет доставки с повторами
+type WebhookDispatcher interface {
+	Publish(context.Context, model.Event) error
+	Run(context.Context)
+	DispatchDueDeliveries(context.Context) (int, error)
+}
+
+type webhookDispatcher struct {
+	WebhookRepository repository.WebhookRepository
+	client            *http.Client
+	interval          time.Duration
+	maxAttempts       int
+	retryBase         time.Duration
+	logger            *slog.Logger
+}
+
+func NewWebhookDispatcher(webhookRepository repository.WebhookRepository, client *http.Client, interval time.Duration, maxAttempts int, retryBase time.Duration, logger *slog.Logger) WebhookDispatcher {
+	return &webhookDispatcher{webhookRepository, client, interval, maxAttempts, retryBase, logger}
+}
+
+// Publish создаёт доставки для подписок организации тендера. Вызывается relay outbox,
+// поэтому повторная публикация того же события не создаёт дублей
+func (d *webhookDispatcher) Publish(ctx context.Context, event model.Event) error {
+	visible, err := event.VisibleToTender()
+	if err != nil {
+		return fmt.Errorf("Error checking event visibility, %w", err)
+	}
+	if !visible {
+		return nil
+	}
+
+	webhooks, err := d.WebhookRepository.GetSubscribedWebhooks(ctx, event.TenderID, event.Type)
+	if err != nil {
+		return fmt.Errorf("Error getting subscribed webhooks, %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("Error encoding webhook payload, %w", err)
+	}
+
+	now := time.Now()
+	for _, webhook := range webhooks {
+		delivery := &model.WebhookDelivery{
+			ID:            uuid.NewString(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       body,
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := d.WebhookRepository.CreateWebhookDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("Error creating webhook delivery, %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Run отправляет доставки, время которых пришло, раз в interval, пока не отменён контекст
+func (d *webhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		dispatched, err := d.DispatchDueDeliveries(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.ErrorContext(ctx, "Error dispatching webhook deliveries", slog.Any("error", err))
+		}
+		if dispatched > 0 {
+			d.logger.InfoContext(ctx, "Dispatched webhook deliveries", slog.Int("count", dispatched))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *webhookDispatcher) DispatchDueDeliveries(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		now := time.Now()
+		// Доставки откладываются на время всей пачки, чтобы другой экземпляр не взял их повторно
+		leaseUntil := now.Add(d.client.Timeout*webhookBatchSize + time.Minute)
+		dispatches, err := d.WebhookRepository.ClaimWebhookDeliveries(ctx, now, leaseUntil, webhookBatchSize)
+		if err != nil {
+			return dispatched, fmt.Errorf("Error claiming webhook deliveries, %w", err)
+		}
+
+		for i := range dispatches {
+			if err := d.deliver(ctx, &dispatches[i]); err != nil {
+				return dispatched, err
+			}
+			dispatched++
+		}
+
+		if len(dispatches) < webhookBatchSize {
+			return dispatched, nil
+		}
+	}
+}
+
+func (d *webhookDispatcher) deliver(ctx context.Context, dispatch *model.WebhookDispatch) error {
+	statusCode, sendErr := d.send(ctx, dispatch)
+	if ctx.Err() != nil {
+		// Отправку прервала остановка сервиса - доставка вернётся в очередь после аренды
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	delivery := &dispatch.WebhookDelivery
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if sendErr == nil {
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+	} else {
+		lastError := sendErr.Error()
+		delivery.LastError = &lastError
+		if delivery.Attempts >= d.maxAttempts {
+			delivery.Status = model.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+		}
+		d.logger.WarnContext(ctx, "Webhook delivery attempt failed", slog.String("deliveryID", delivery.ID), slog.Int("attempts", delivery.Attempts), slog.Any("error", sendErr))
+	}
+
+	if err := d.WebhookRepository.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("Error saving webhook delivery %s, %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// send возвращает код ответа, если сервер ответил; успехом считается только 2xx
+func (d *webhookDispatcher) send(ctx context.Context, dispatch *model.WebhookDispatch) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(dispatch.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(dispatch.EventType))
+	req.Header.Set(WebhookDeliveryHeader, dispatch.ID)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(dispatch.Secret, dispatch.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Тело ответа не нужно, но дочитывается, чтобы соединение вернулось в пул
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff удваивает паузу после каждой неудачной попытки
+func (d *webhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryBase
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookBackoff)
+}
+
+// SignWebhookPayload считает подпись, которую получатель сверяет с заголовком X-Webhook-Signature-256
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebhookRepository отдаёт заранее заданные доставки и запоминает сохранённые
+type fakeWebhookRepository struct {
+	repository.WebhookRepository
+	webhooks   []model.Webhook
+	dispatches []model.WebhookDispatch
+	created    []model.WebhookDelivery
+	updated    []model.WebhookDelivery
+}
+
+func (r *fakeWebhookRepository) GetSubscribedWebhooks(ctx context.Context, tenderID string, eventType model.EventType) ([]model.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *fakeWebhookRepository) CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	r.created = append(r.created, *delivery)
+	return nil
+}
+
+func (r *fakeWebhookRepository) ClaimWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.WebhookDispatch, error) {
+	dispatches := r.dispatches
+	r.dispatches = nil
+	return dispatches, nil
+}
+
+func (r *fakeWebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	r.updated = append(r.updated, *delivery)
+	return nil
+}
+
+func newTestDispatcher(repo *fakeWebhookRepository, maxAttempts int) *webhookDispatcher {
+	return &webhookDispatcher{
+		WebhookRepository: repo,
+		client:            &http.Client{Timeout: time.Second},
+		interval:          time.Second,
+		maxAttempts:       maxAttempts,
+		retryBase:         time.Minute,
+		logger:            slog.Default(),
+	}
+}
+
+func TestDispatchDueDeliveries(t *testing.T) {
+	payload := []byte(`{"id":42,"type":"BidSubmitted"}`)
+	secret := "0123456789abcdef"
+
+	newDispatch := func(url string, attempts int) model.WebhookDispatch {
+		return model.WebhookDispatch{
+			WebhookDelivery: model.WebhookDelivery{
+				ID:        "delivery-1",
+				WebhookID: "webhook-1",
+				EventID:   42,
+				EventType: model.EventBidSubmitted,
+				Payload:   payload,
+				Status:    model.WebhookDeliveryPending,
+				Attempts:  attempts,
+			},
+			URL:    url,
+			Secret: secret,
+		}
+	}
+
+	t.Run("signed delivery", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, payload, body)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "BidSubmitted", r.Header.Get(WebhookEventHeader))
+			assert.Equal(t, "delivery-1", r.Header.Get(WebhookDeliveryHeader))
+			assert.Equal(t, "sha256="+SignWebhookPayload(secret, body), r.Header.Get(WebhookSignatureHeader))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		repo := &fakeWebhookRepository{dispatches: []model.WebhookDispatch{newDispatch(server.URL, 0)}}
+		dispatched, err := newTestDispatcher(repo, 3).DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.WebhookDeliveryDelivered, repo.updated[0].Status)
+		assert.Equal(t, 1, repo.updated[0].Attempts)
+		assert.Equal(t, http.StatusNoContent, *repo.updated[0].LastStatusCode)
+		assert.NotNil(t, repo.updated[0].DeliveredAt)
+		assert.Nil(t, repo.updated[0].LastError)
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		repo := &fakeWebhookRepository{dispatches: []model.WebhookDispatch{newDispatch(server.URL, 2)}}
+		start := time.Now()
+		_, err := newTestDispatcher(repo, 5).DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+
+		require.Len(t, repo.updated, 1)
+		delivery := repo.updated[0]
+		assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Equal(t, http.StatusBadGateway, *delivery.LastStatusCode)
+		assert.Contains(t, *delivery.LastError, "502")
+		// Третья неудача - пауза 4 базовых интервала
+		assert.WithinDuration(t, start.Add(4*time.Minute), delivery.NextAttemptAt, 5*time.Second)
+	})
+
+	t.Run("failed after max attempts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		repo := &fakeWebhookRepository{dispatches: []model.WebhookDispatch{newDispatch(server.URL, 2)}}
+		_, err := newTestDispatcher(repo, 3).DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.WebhookDeliveryFailed, repo.updated[0].Status)
+		assert.Equal(t, 3, repo.updated[0].Attempts)
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+
+		repo := &fakeWebhookRepository{dispatches: []model.WebhookDispatch{newDispatch(url, 0)}}
+		_, err := newTestDispatcher(repo, 3).DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, model.WebhookDeliveryPending, repo.updated[0].Status)
+		assert.Nil(t, repo.updated[0].LastStatusCode)
+		assert.NotNil(t, repo.updated[0].LastError)
+	})
+}
+
+func TestWebhookDispatcherPublish(t *testing.T) {
+	webhooks := []model.Webhook{{ID: "webhook-1"}, {ID: "webhook-2"}}
+
+	t.Run("creates deliveries for subscribers", func(t *testing.T) {
+		repo := &fakeWebhookRepository{webhooks: webhooks}
+		event, err := model.NewBidEvent(model.BidStatusCreated, &model.Bid{ID: "bid-1", TenderID: "tender-1", Status: model.BidStatusPublished})
+		require.NoError(t, err)
+		event.ID = 42
+
+		assert.NoError(t, newTestDispatcher(repo, 3).Publish(context.Background(), *event))
+		require.Len(t, repo.created, 2)
+		assert.Equal(t, int64(42), repo.created[0].EventID)
+		assert.Equal(t, model.EventBidSubmitted, repo.created[0].EventType)
+		assert.Equal(t, "webhook-2", repo.created[1].WebhookID)
+		assert.JSONEq(t, string(repo.created[0].Payload), string(repo.created[1].Payload))
+	})
+
+	t.Run("skips unpublished bids", func(t *testing.T) {
+		repo := &fakeWebhookRepository{webhooks: webhooks}
+		event, err := model.NewBidEvent("", &model.Bid{ID: "bid-1", TenderID: "tender-1", Status: model.BidStatusCreated})
+		require.NoError(t, err)
+
+		assert.NoError(t, newTestDispatcher(repo, 3).Publish(context.Background(), *event))
+		assert.Empty(t, repo.created)
+	})
+}
+
+func TestWebhookClient(t *testing.T) {
+	t.Run("public addresses", func(t *testing.T) {
+		tests := []struct {
+			ip     string
+			public bool
+		}{
+			{"93.184.216.34", true},
+			{"2606:2800:220:1:248:1893:25c8:1946", true},
+			{"127.0.0.1", false},
+			{"::1", false},
+			{"10.0.0.5", false},
+			{"172.16.0.1", false},
+			{"192.168.1.1", false},
+			{"169.254.169.254", false},
+			{"100.100.100.200", false},
+			{"0.0.0.0", false},
+			{"::ffff:127.0.0.1", false},
+			{"fd00::1", false},
+			{"fe80::1", false},
+			{"0.1.2.3", false},
+			{"192.0.0.8", false},
+			{"198.18.0.1", false},
+			{"198.19.255.255", false},
+			{"64:ff9b::7f00:1", false},
+			{"64:ff9b::a00:5", false},
+			{"64:ff9b::5db8:d822", true},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.public, isPublicIP(net.ParseIP(tt.ip)), tt.ip)
+		}
+	})
+
+	t.Run("loopback is refused on dial", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		_, err := NewWebhookClient(time.Second).Post(server.URL, "application/json", nil)
+		assert.ErrorIs(t, err, errWebhookAddressNotAllowed)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		client := NewWebhookClient(time.Second)
+		assert.ErrorIs(t, client.CheckRedirect(nil, nil), http.ErrUseLastResponse)
+	})
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"Backend-trainee-assignment-autumn-2024/internal/model"
+	"Backend-trainee-assignment-autumn-2024/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhookRequest *model.CreateWebhookRequest) (*model.Webhook, error)
+	GetWebhooks(ctx context.Context, organizationID string, username string) ([]model.Webhook, error)
+	EditWebhook(ctx context.Context, organizationID string, webhookID string, username string, updateData model.UpdateWebhookData) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, organizationID string, webhookID string, username string) error
+	GetWebhookDeliveries(ctx context.Context, organizationID string, webhookID string, username string, limit int, offset int) ([]model.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, organizationID string, webhookID string, deliveryID string, username string) (*model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepository      repository.WebhookRepository
+	organizationRepository repository.OrganizationRepository
+	userRepository         repository.UserRepository
+	logger                 *slog.Logger
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository, organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository, logger *slog.Logger) WebhookService {
+	return &webhookService{webhookRepository, organizationRepository, userRepository, logger}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, webhookRequest *model.CreateWebhookRequest) (*model.Webhook, error) {
+	if err := s.checkResponsible(ctx, webhookRequest.OrganizationID, webhookRequest.Username); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook := &model.Webhook{
+		ID:             uuid.NewString(),
+		OrganizationID: webhookRequest.OrganizationID,
+		URL:            webhookRequest.URL,
+		Secret:         webhookRequest.Secret,
+		EventTypes:     webhookRequest.EventTypes,
+		CreatedBy:      webhookRequest.Username,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	webhook, err := s.webhookRepository.CreateWebhook(ctx, webhook)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error creating webhook", slog.Any("error", err))
+		return nil, fmt.Errorf("Error creating webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context, organizationID string, username string) ([]model.Webhook, error) {
+	if err := s.checkResponsible(ctx, organizationID, username); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepository.GetWebhooks(ctx, organizationID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting webhooks", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *webhookService) EditWebhook(ctx context.Context, organizationID string, webhookID string, username string, updateData model.UpdateWebhookData) (*model.Webhook, error) {
+	webhook, err := s.getWebhook(ctx, organizationID, webhookID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateData.URL != nil {
+		webhook.URL = *updateData.URL
+	}
+
+	if updateData.Secret != nil {
+		webhook.Secret = *updateData.Secret
+	}
+
+	if updateData.EventTypes != nil {
+		webhook.EventTypes = updateData.EventTypes
+	}
+
+	webhook, err = s.webhookRepository.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error updating webhook", slog.Any("error", err))
+		if errors.Is(err, model.ErrWebhookNotFound) {
+			return nil, model.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("Error updating webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, organizationID string, webhookID string, username string) error {
+	if _, err := s.getWebhook(ctx, organizationID, webhookID, username); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepository.DeleteWebhook(ctx, webhookID); err != nil {
+		s.logger.ErrorContext(ctx, "Error deleting webhook", slog.Any("error", err))
+		if errors.Is(err, model.ErrWebhookNotFound) {
+			return model.ErrWebhookNotFound
+		}
+		return fmt.Errorf("Error deleting webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webhookService) GetWebhookDeliveries(ctx context.Context, organizationID string, webhookID string, username string, limit int, offset int) ([]model.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, organizationID, webhookID, username); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepository.GetWebhookDeliveries(ctx, webhookID, limit, offset)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting webhook deliveries", slog.Any("error", err))
+		return nil, fmt.Errorf("Error getting webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverWebhook ставит доставку в очередь заново, отправит её диспетчер
+func (s *webhookService) RedeliverWebhook(ctx context.Context, organizationID string, webhookID string, deliveryID string, username string) (*model.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, organizationID, webhookID, username); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepository.GetWebhookDeliveryById(ctx, deliveryID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting webhook delivery", slog.Any("error", err))
+		if errors.Is(err, model.ErrDeliveryNotFound) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("Error getting webhook delivery: %w", err)
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, model.ErrDeliveryNotFound
+	}
+
+	delivery, err = s.webhookRepository.RedeliverWebhookDelivery(ctx, deliveryID, time.Now())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error redelivering webhook delivery", slog.Any("error", err))
+		if errors.Is(err, model.ErrDeliveryNotFound) {
+			return nil, model.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("Error redelivering webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// getWebhook проверяет права и что подписка принадлежит организации из пути
+func (s *webhookService) getWebhook(ctx context.Context, organizationID string, webhookID string, username string) (*model.Webhook, error) {
+	if err := s.checkResponsible(ctx, organizationID, username); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepository.GetWebhookById(ctx, webhookID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting webhook", slog.Any("error", err))
+		if errors.Is(err, model.ErrWebhookNotFound) {
+			return nil, model.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("Error getting webhook: %w", err)
+	}
+	if webhook.OrganizationID != organizationID {
+		return nil, model.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) checkResponsible(ctx context.Context, organizationID string, username string) error {
+	_, err := s.userRepository.GetUserByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting user", slog.Any("error", err))
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ErrUserNotFound
+		}
+		return fmt.Errorf("Error getting user: %w", err)
+	}
+
+	_, err = s.organizationRepository.GetOrganizationById(ctx, organizationID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error getting organization", slog.Any("error", err))
+		if errors.Is(err, model.ErrOrganizationNotFound) {
+			return model.ErrOrganizationNotFound
+		}
+		return fmt.Errorf("Error getting organization: %w", err)
+	}
+
+	isResponsible, err := s.organizationRepository.IsUserResponsibleForOrganization(ctx, organizationID, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error checking user is responsible for organization", slog.Any("error", err))
+		return fmt.Errorf("Error checking user is responsible for organization: %w", err)
+	}
+	if !isResponsible {
+		s.logger.ErrorContext(ctx, "User is not responsible for organization", slog.String("username", username), slog.String("organizationID", organizationID))
+		return model.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrFileNotFound = errors.New("file not found")
+
+// FileStorage хранит содержимое вложений, метаданные лежат в базе
+type FileStorage interface {
+	Save(context.Context, string, io.Reader) error
+	Open(context.Context, string) (io.ReadCloser, error)
+	Delete(context.Context, string) error
+}
@@ -0,0 +1,6 @@
+
+DROP INDEX bid_review_bid_id_idx;
+
+ALTER TABLE bid_review
+    DROP COLUMN author_username,
+    ALTER COLUMN description DROP NOT NULL;
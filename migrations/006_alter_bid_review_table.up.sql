@@ -0,0 +1,6 @@
+
+ALTER TABLE bid_review
+    ADD COLUMN author_username VARCHAR(50) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    ALTER COLUMN description SET NOT NULL;
+
+CREATE INDEX bid_review_bid_id_idx ON bid_review (bid_id);
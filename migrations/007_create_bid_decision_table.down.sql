@@ -0,0 +1,3 @@
+
+DROP TABLE bid_decision;
+DROP TYPE bid_decision_type;
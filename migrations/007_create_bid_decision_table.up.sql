@@ -0,0 +1,14 @@
+
+CREATE TYPE bid_decision_type AS ENUM (
+    'Approved',
+    'Rejected'
+);
+
+CREATE TABLE bid_decision (
+    id VARCHAR PRIMARY KEY,
+    bid_id VARCHAR REFERENCES bid(id) ON DELETE CASCADE NOT NULL,
+    username VARCHAR(50) REFERENCES employee(username) ON DELETE CASCADE NOT NULL,
+    decision bid_decision_type NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, username)
+);
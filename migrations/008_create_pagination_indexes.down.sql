@@ -0,0 +1,4 @@
+DROP INDEX bid_creator_username_name_id_idx;
+DROP INDEX bid_tender_id_name_id_idx;
+DROP INDEX tender_creator_username_name_id_idx;
+DROP INDEX tender_name_id_idx;
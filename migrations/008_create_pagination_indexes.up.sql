@@ -0,0 +1,4 @@
+CREATE INDEX tender_name_id_idx ON tender (name, id);
+CREATE INDEX tender_creator_username_name_id_idx ON tender (creator_username, name, id);
+CREATE INDEX bid_tender_id_name_id_idx ON bid (tender_id, name, id);
+CREATE INDEX bid_creator_username_name_id_idx ON bid (creator_username, name, id);
@@ -0,0 +1,5 @@
+DROP INDEX bid_search_vector_idx;
+DROP INDEX tender_search_vector_idx;
+
+ALTER TABLE bid DROP COLUMN search_vector;
+ALTER TABLE tender DROP COLUMN search_vector;
@@ -0,0 +1,20 @@
+DROP INDEX bid_tender_id_price_id_idx;
+
+ALTER TABLE bid_history
+    DROP COLUMN currency,
+    DROP COLUMN price;
+
+ALTER TABLE bid
+    DROP COLUMN currency,
+    DROP COLUMN price;
+
+ALTER TABLE tender_history
+    DROP COLUMN budget_currency,
+    DROP COLUMN budget_max,
+    DROP COLUMN budget_min;
+
+ALTER TABLE tender
+    DROP CONSTRAINT tender_budget_range_check,
+    DROP COLUMN budget_currency,
+    DROP COLUMN budget_max,
+    DROP COLUMN budget_min;
@@ -0,0 +1,20 @@
+ALTER TABLE tender
+    ADD COLUMN budget_min NUMERIC(15, 2),
+    ADD COLUMN budget_max NUMERIC(15, 2),
+    ADD COLUMN budget_currency VARCHAR(3),
+    ADD CONSTRAINT tender_budget_range_check CHECK (budget_min IS NULL OR budget_max IS NULL OR budget_min <= budget_max);
+
+ALTER TABLE tender_history
+    ADD COLUMN budget_min NUMERIC(15, 2),
+    ADD COLUMN budget_max NUMERIC(15, 2),
+    ADD COLUMN budget_currency VARCHAR(3);
+
+ALTER TABLE bid
+    ADD COLUMN price NUMERIC(15, 2) CHECK (price >= 0),
+    ADD COLUMN currency VARCHAR(3);
+
+ALTER TABLE bid_history
+    ADD COLUMN price NUMERIC(15, 2),
+    ADD COLUMN currency VARCHAR(3);
+
+CREATE INDEX bid_tender_id_price_id_idx ON bid (tender_id, (COALESCE(price, 'Infinity'::numeric)), id);
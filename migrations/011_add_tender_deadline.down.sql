@@ -0,0 +1,4 @@
+DROP INDEX tender_published_deadline_idx;
+
+ALTER TABLE tender_history DROP COLUMN deadline;
+ALTER TABLE tender DROP COLUMN deadline;
@@ -0,0 +1,4 @@
+ALTER TABLE tender ADD COLUMN deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tender_history ADD COLUMN deadline TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX tender_published_deadline_idx ON tender (deadline) WHERE status = 'Published';
@@ -0,0 +1,2 @@
+DROP TABLE bid_score;
+DROP TABLE tender_criterion;
@@ -0,0 +1,18 @@
+CREATE TABLE tender_criterion (
+    id VARCHAR PRIMARY KEY,
+    tender_id VARCHAR REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    weight INT NOT NULL CHECK (weight BETWEEN 1 AND 100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, name)
+);
+
+CREATE TABLE bid_score (
+    id VARCHAR PRIMARY KEY,
+    bid_id VARCHAR REFERENCES bid(id) ON DELETE CASCADE NOT NULL,
+    criterion_id VARCHAR REFERENCES tender_criterion(id) ON DELETE CASCADE NOT NULL,
+    username VARCHAR(50) REFERENCES employee(username) ON DELETE CASCADE NOT NULL,
+    score INT NOT NULL CHECK (score BETWEEN 0 AND 10),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, criterion_id, username)
+);
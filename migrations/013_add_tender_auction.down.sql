@@ -0,0 +1,12 @@
+DROP INDEX tender_published_auction_ends_at_idx;
+
+DROP TABLE auction_result;
+
+ALTER TABLE tender_history
+    DROP COLUMN auction_ends_at,
+    DROP COLUMN auction;
+
+ALTER TABLE tender
+    DROP CONSTRAINT tender_auction_ends_at_check,
+    DROP COLUMN auction_ends_at,
+    DROP COLUMN auction;
@@ -0,0 +1,18 @@
+ALTER TABLE tender
+    ADD COLUMN auction BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN auction_ends_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT tender_auction_ends_at_check CHECK (NOT auction OR auction_ends_at IS NOT NULL);
+
+ALTER TABLE tender_history
+    ADD COLUMN auction BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN auction_ends_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE auction_result (
+    tender_id VARCHAR PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id VARCHAR REFERENCES bid(id) ON DELETE SET NULL,
+    price NUMERIC(15, 2),
+    currency VARCHAR(3),
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX tender_published_auction_ends_at_idx ON tender (auction_ends_at) WHERE auction AND status = 'Published';
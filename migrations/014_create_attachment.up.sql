@@ -0,0 +1,14 @@
+CREATE TABLE attachment (
+    id VARCHAR PRIMARY KEY,
+    owner_type VARCHAR(10) NOT NULL CHECK (owner_type IN ('Tender', 'Bid')),
+    owner_id VARCHAR NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR NOT NULL UNIQUE,
+    created_by VARCHAR(50) REFERENCES employee(username) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX attachment_owner_idx ON attachment (owner_type, owner_id, created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    aggregate_type VARCHAR(10) NOT NULL CHECK (aggregate_type IN ('Tender', 'Bid')),
+    aggregate_id VARCHAR NOT NULL,
+    tender_id VARCHAR NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX outbox_event_unpublished_idx ON outbox_event (id) WHERE published_at IS NULL;
+CREATE INDEX outbox_event_tender_idx ON outbox_event (tender_id, id);
@@ -0,0 +1,3 @@
+DROP TABLE webhook_delivery;
+DROP TYPE webhook_delivery_status;
+DROP TABLE webhook;
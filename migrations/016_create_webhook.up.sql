@@ -0,0 +1,37 @@
+CREATE TABLE webhook (
+    id VARCHAR PRIMARY KEY,
+    organization_id VARCHAR REFERENCES organization(id) ON DELETE CASCADE NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types VARCHAR(50)[] NOT NULL,
+    created_by VARCHAR(50) REFERENCES employee(username) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX webhook_organization_idx ON webhook (organization_id);
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'Pending',
+    'Delivered',
+    'Failed'
+);
+
+CREATE TABLE webhook_delivery (
+    id VARCHAR PRIMARY KEY,
+    webhook_id VARCHAR REFERENCES webhook(id) ON DELETE CASCADE NOT NULL,
+    event_id BIGINT REFERENCES outbox_event(id) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_status_code INT,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'Pending';
+CREATE INDEX webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, created_at DESC);
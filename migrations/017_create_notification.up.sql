@@ -0,0 +1,20 @@
+CREATE TYPE notification_type AS ENUM (
+    'BidApproved',
+    'BidRejected',
+    'BidFeedback',
+    'TenderClosed'
+);
+
+CREATE TABLE notification (
+    id VARCHAR PRIMARY KEY,
+    username VARCHAR(50) REFERENCES employee(username) ON DELETE CASCADE NOT NULL,
+    type notification_type NOT NULL,
+    tender_id VARCHAR REFERENCES tender(id) ON DELETE CASCADE NOT NULL,
+    bid_id VARCHAR REFERENCES bid(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notification_username_idx ON notification (username, created_at DESC, id);
+CREATE INDEX notification_unread_idx ON notification (username) WHERE read_at IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE employee
+    DROP COLUMN email_notifications,
+    DROP COLUMN language,
+    DROP COLUMN email;
@@ -0,0 +1,4 @@
+ALTER TABLE employee
+    ADD COLUMN email VARCHAR(254),
+    ADD COLUMN language VARCHAR(2) NOT NULL DEFAULT 'ru' CHECK (language IN ('ru', 'en')),
+    ADD COLUMN email_notifications BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,8 @@
+-- Токен выдаётся только по паролю; сотрудник без пароля войти не может
+ALTER TABLE employee
+    ADD COLUMN password_hash VARCHAR(60);
+
+-- Тестовые сотрудники из 004 получают пароль "password" для локальной разработки
+UPDATE employee
+SET password_hash = '$2a$10$XeYgM.I.9LnmMWujQRwwGuuCXsTDhkl1ONoMvzBLfXzoucJQCWYx2'
+WHERE id IN ('user1_id', 'user2_id', 'user3_id') AND password_hash IS NULL;
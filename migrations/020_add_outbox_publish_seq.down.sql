@@ -0,0 +1,5 @@
+DROP INDEX outbox_event_tender_publish_seq_idx;
+DROP INDEX outbox_event_publish_seq_idx;
+
+ALTER TABLE outbox_event
+    DROP COLUMN publish_seq;
@@ -0,0 +1,12 @@
+-- Номер публикации выдаёт relay под общей блокировкой, поэтому он растёт в порядке фиксации,
+-- а не вставки: по нему поток событий возобновляется без пропуска поздно зафиксированных событий
+ALTER TABLE outbox_event
+    ADD COLUMN publish_seq BIGINT;
+
+-- Уже опубликованные события сохраняют свои id, чтобы прежние Last-Event-ID остались действительны
+UPDATE outbox_event
+SET publish_seq = id
+WHERE published_at IS NOT NULL;
+
+CREATE UNIQUE INDEX outbox_event_publish_seq_idx ON outbox_event (publish_seq);
+CREATE INDEX outbox_event_tender_publish_seq_idx ON outbox_event (tender_id, publish_seq) WHERE publish_seq IS NOT NULL;
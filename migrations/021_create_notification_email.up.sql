@@ -0,0 +1,12 @@
+-- Письмо к уведомлению ставится в очередь вместе с ним, отправляет его фоновый EmailDispatcher
+CREATE TABLE notification_email (
+    notification_id VARCHAR PRIMARY KEY REFERENCES notification(id) ON DELETE CASCADE,
+    feedback TEXT NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'Pending' CHECK (status IN ('Pending', 'Sent', 'Skipped', 'Failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX notification_email_pending_idx ON notification_email (next_attempt_at) WHERE status = 'Pending';